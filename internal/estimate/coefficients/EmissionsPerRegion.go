@@ -13,8 +13,8 @@ import (
 	"github.com/yunabe/easycsv"
 )
 
-// EmissionsPerRegion is a map of regions to their emissions
-var EmissionsPerRegion map[string]Emissions
+// EmissionsPerRegion is a map of regions to their emissions, per provider
+var EmissionsPerRegion = map[providers.Provider]map[string]Emissions{}
 
 // Emissions is the emissions of a region
 type Emissions struct {
@@ -31,16 +31,18 @@ func RegionEmission(provider providers.Provider, region string) (*Emissions, err
 		dataFile = "aws_co2_region.csv"
 	case providers.GCP:
 		dataFile = "gcp_co2_region.csv"
+	case providers.AZURE:
+		dataFile = "azure_co2_region.csv"
 	default:
 		return nil, errors.New("Provider not supported")
 	}
-	if EmissionsPerRegion == nil {
-		EmissionsPerRegion = loadEmissionsPerRegion(dataFile)
+	if EmissionsPerRegion[provider] == nil {
+		EmissionsPerRegion[provider] = loadEmissionsPerRegion(dataFile)
 	}
 	if region == "" {
 		return nil, errors.New("Region cannot be empty")
 	}
-	emissions, ok := EmissionsPerRegion[region]
+	emissions, ok := EmissionsPerRegion[provider][region]
 	if !ok {
 		return nil, errors.Errorf("Region does not exist: '%v'", region)
 	}
@@ -53,12 +55,12 @@ type emissionsCSV struct {
 	GridCarbonIntensity float64 `name:"Grid carbon intensity (gCO2eq / kWh)"`
 }
 
-// Source: Google
+// Source: Google (GCP), Cloud Carbon Footprint (AWS, Azure)
 func loadEmissionsPerRegion(dataFile string) map[string]Emissions {
 	// Read the CSV records
 	var records []emissionsCSV
 	regionEmissionFile := data.ReadDataFile(dataFile)
-	log.Debugf("reading GCP region/grid emissions from: %v", dataFile)
+	log.Debugf("reading region/grid emissions from: %v", dataFile)
 	if err := easycsv.NewReader(strings.NewReader(string(regionEmissionFile))).ReadAll(&records); err != nil {
 		log.Fatal(err)
 	}
@@ -0,0 +1,57 @@
+package coefficients
+
+import (
+	"strings"
+
+	"github.com/carboniferio/carbonifer/internal/data"
+	"github.com/carboniferio/carbonifer/internal/providers"
+	"github.com/shopspring/decimal"
+	log "github.com/sirupsen/logrus"
+	"github.com/yunabe/easycsv"
+)
+
+var puePerRegion map[string]decimal.Decimal
+
+type pueCSV struct {
+	Provider string  `name:"provider"`
+	Region   string  `name:"region"`
+	PUE      float64 `name:"pue"`
+}
+
+// RegionPUE returns the PUE of a region, or the PUE average of the provider if the region is not listed
+func RegionPUE(provider providers.Provider, region string) decimal.Decimal {
+	if puePerRegion == nil {
+		puePerRegion = loadPUEPerRegion()
+	}
+	if pue, ok := puePerRegion[regionPUEKey(provider.String(), region)]; ok {
+		return pue
+	}
+	log.Debugf("No PUE for region '%v' of %v, using provider average", region, provider)
+	return GetEnergyCoefficients().GetByProvider(provider).PueAverage
+}
+
+func loadPUEPerRegion() map[string]decimal.Decimal {
+	// Read the CSV records
+	var records []pueCSV
+	pueFile := data.ReadDataFile("pue_regions.csv")
+	if err := easycsv.NewReader(strings.NewReader(string(pueFile))).ReadAll(&records); err != nil {
+		log.Fatal(err)
+	}
+
+	// Create a map to store the data
+	data := make(map[string]decimal.Decimal)
+
+	// Iterate over the records and add them to the map
+	for _, record := range records {
+		recordProvider, err := providers.ParseProvider(record.Provider)
+		if err != nil {
+			log.Fatalf("Invalid provider in PUE per region: %v", err)
+		}
+		data[regionPUEKey(recordProvider.String(), record.Region)] = decimal.NewFromFloat(record.PUE)
+	}
+	return data
+}
+
+func regionPUEKey(provider string, region string) string {
+	return strings.ToLower(provider + "/" + region)
+}
@@ -2,7 +2,6 @@ package coefficients
 
 import (
 	"encoding/json"
-	"reflect"
 
 	"github.com/carboniferio/carbonifer/internal/data"
 	"github.com/carboniferio/carbonifer/internal/providers"
@@ -44,12 +43,14 @@ func GetEnergyCoefficients() *CoefficientsProviders {
 
 // GetByProvider returns the coefficients for the energy estimation of a provider
 func (cps *CoefficientsProviders) GetByProvider(provider providers.Provider) Coefficients {
-	return coefficientsPerProviders.getByProviderName(provider.String())
-}
-
-func (cps *CoefficientsProviders) getByProviderName(name string) Coefficients {
-	r := reflect.ValueOf(cps)
-	coefficientsVal := reflect.Indirect(r).FieldByName(name)
-	coefficients := coefficientsVal.Interface().(Coefficients)
-	return coefficients
+	switch provider {
+	case providers.AWS:
+		return cps.AWS
+	case providers.GCP:
+		return cps.GCP
+	case providers.AZURE:
+		return cps.Azure
+	default:
+		return Coefficients{}
+	}
 }
@@ -0,0 +1,71 @@
+package coefficients
+
+import (
+	"encoding/json"
+
+	"github.com/carboniferio/carbonifer/internal/data"
+	"github.com/carboniferio/carbonifer/internal/providers"
+	"github.com/shopspring/decimal"
+	log "github.com/sirupsen/logrus"
+)
+
+// WaterCoefficients is a struct that contains the coefficients for the water estimation, in L/kWh
+type WaterCoefficients struct {
+	// WUE (Water Usage Effectiveness) is the water used on-site (cooling) per kWh of IT energy
+	WUEAverage decimal.Decimal            `json:"wue_average"`
+	WUERegions map[string]decimal.Decimal `json:"wue_regions"`
+	// Grid water intensity is the water used off-site to generate a kWh of electricity
+	GridWaterIntensityAverage decimal.Decimal            `json:"grid_water_intensity_average"`
+	GridWaterIntensityRegions map[string]decimal.Decimal `json:"grid_water_intensity_regions"`
+}
+
+// WaterCoefficientsProviders is a struct that contains the coefficients for the water estimation per provider
+type WaterCoefficientsProviders struct {
+	AWS   WaterCoefficients `json:"AWS"`
+	GCP   WaterCoefficients `json:"GCP"`
+	Azure WaterCoefficients `json:"Azure"`
+}
+
+var waterCoefficientsPerProviders *WaterCoefficientsProviders
+
+// GetWaterCoefficients returns the coefficients for the water estimation
+func GetWaterCoefficients() *WaterCoefficientsProviders {
+	if waterCoefficientsPerProviders == nil {
+		waterCoefFile := data.ReadDataFile("water_coefficients.json")
+		err := json.Unmarshal(waterCoefFile, &waterCoefficientsPerProviders)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+	return waterCoefficientsPerProviders
+}
+
+// GetByProvider returns the coefficients for the water estimation of a provider
+func (wcps *WaterCoefficientsProviders) GetByProvider(provider providers.Provider) WaterCoefficients {
+	switch provider {
+	case providers.AWS:
+		return wcps.AWS
+	case providers.GCP:
+		return wcps.GCP
+	case providers.AZURE:
+		return wcps.Azure
+	default:
+		return WaterCoefficients{}
+	}
+}
+
+// WUE returns the WUE of a region, or the provider average if the region is not listed
+func (wc WaterCoefficients) WUE(region string) decimal.Decimal {
+	if wue, ok := wc.WUERegions[region]; ok {
+		return wue
+	}
+	return wc.WUEAverage
+}
+
+// GridWaterIntensity returns the grid water intensity of a region, or the provider average if the region is not listed
+func (wc WaterCoefficients) GridWaterIntensity(region string) decimal.Decimal {
+	if intensity, ok := wc.GridWaterIntensityRegions[region]; ok {
+		return intensity
+	}
+	return wc.GridWaterIntensityAverage
+}
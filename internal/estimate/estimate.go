@@ -23,9 +23,22 @@ func EstimateResources(resourceList map[string]resources.Resource) estimation.Es
 	estimationTotal := estimation.EstimationTotal{
 		Power:           decimal.Zero,
 		CarbonEmissions: decimal.Zero,
+		Water:           decimal.Zero,
 		ResourcesCount:  decimal.Zero,
 	}
+	var workloads []resources.WorkloadResource
+	var needsUsageResources []resources.Resource
 	for _, resource := range resourceList {
+		// Workloads are a share of their hosts, allocated once all hosts are estimated
+		if workload, ok := resource.(resources.WorkloadResource); ok {
+			workloads = append(workloads, workload)
+			continue
+		}
+		if computeResource, ok := resource.(resources.ComputeResource); ok && estimate.NeedsUsage(&computeResource) {
+			logrus.Warnf("Cannot estimate %v without its usage, declare it in 'usages' config", resource.GetAddress())
+			needsUsageResources = append(needsUsageResources, resource)
+			continue
+		}
 		estimationResource, uerr := EstimateResource(resource)
 		if uerr != nil {
 			logrus.Warnf("Skipping unsupported provider %v: %v.%v", uerr.Provider, resource.GetIdentification().ResourceType, resource.GetIdentification().Name)
@@ -33,20 +46,31 @@ func EstimateResources(resourceList map[string]resources.Resource) estimation.Es
 
 		if resource.IsSupported() {
 			estimationResources = append(estimationResources, *estimationResource)
+			// VMs placed on a dedicated host can also be allocated a share of their host
+			if computeResource, ok := resource.(resources.ComputeResource); ok {
+				if workload := estimate.GetDedicatedHostWorkload(&computeResource); workload != nil {
+					workloads = append(workloads, *workload)
+				}
+			}
 		} else {
 			unsupportedResources = append(unsupportedResources, resource)
 		}
 
 		estimationTotal.Power = estimationTotal.Power.Add(estimationResource.Power.Mul(estimationResource.TotalCount))
 		estimationTotal.CarbonEmissions = estimationTotal.CarbonEmissions.Add(estimationResource.CarbonEmissions.Mul(estimationResource.TotalCount))
+		estimationTotal.Water = estimationTotal.Water.Add(estimationResource.Water.Mul(estimationResource.TotalCount))
 		estimationTotal.ResourcesCount = estimationTotal.ResourcesCount.Add(estimationResource.TotalCount)
 	}
 
+	allocations, idleCapacities, unallocatedWorkloads := estimate.AllocateWorkloads(workloads, estimationResources)
+	unsupportedResources = append(unsupportedResources, unallocatedWorkloads...)
+
 	return estimation.EstimationReport{
 		Info: estimation.EstimationInfo{
 			UnitTime:                viper.Get("unit.time").(string),
 			UnitWattTime:            fmt.Sprintf("%s%s", "W", viper.Get("unit.time")),
 			UnitCarbonEmissionsTime: fmt.Sprintf("%sCO2eq/%s", viper.Get("unit.carbon"), viper.Get("unit.time")),
+			UnitWaterTime:           fmt.Sprintf("L/%s", viper.Get("unit.time")),
 			DateTime:                time.Now(),
 			InfoByProvider: map[providers.Provider]estimation.InfoByProvider{
 				providers.GCP: {
@@ -54,13 +78,20 @@ func EstimateResources(resourceList map[string]resources.Resource) estimation.Es
 					AverageGPUUsage: viper.GetFloat64("provider.gcp.avg_gpu_use"),
 				},
 				providers.AWS: {
-					AverageCPUUsage: viper.GetFloat64("provider.gcp.avg_cpu_use"),
-					AverageGPUUsage: viper.GetFloat64("provider.gcp.avg_gpu_use"),
+					AverageCPUUsage: viper.GetFloat64("provider.aws.avg_cpu_use"),
+					AverageGPUUsage: viper.GetFloat64("provider.aws.avg_gpu_use"),
+				},
+				providers.AZURE: {
+					AverageCPUUsage: viper.GetFloat64("provider.azure.avg_cpu_use"),
+					AverageGPUUsage: viper.GetFloat64("provider.azure.avg_gpu_use"),
 				},
 			},
 		},
 		Resources:            estimationResources,
 		UnsupportedResources: unsupportedResources,
+		NeedsUsageResources:  needsUsageResources,
+		Allocations:          allocations,
+		IdleCapacities:       idleCapacities,
 		Total:                estimationTotal,
 	}
 
@@ -83,6 +114,8 @@ func EstimateResource(resource resources.Resource) (*estimation.EstimationResour
 		return estimate.EstimateSupportedResource(resource), nil
 	case providers.GCP:
 		return estimate.EstimateSupportedResource(resource), nil
+	case providers.AZURE:
+		return estimate.EstimateSupportedResource(resource), nil
 	default:
 		return nil, &providers.UnsupportedProviderError{Provider: resource.GetIdentification().Provider.String()}
 	}
@@ -93,6 +126,7 @@ func estimateNotSupported(resource resources.UnsupportedResource) *estimation.Es
 		Resource:        resource,
 		Power:           decimal.Zero,
 		CarbonEmissions: decimal.Zero,
+		Water:           decimal.Zero,
 		AverageCPUUsage: decimal.Zero,
 		TotalCount:      decimal.Zero,
 	}
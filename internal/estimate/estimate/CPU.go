@@ -5,28 +5,67 @@ import (
 	"strings"
 
 	"github.com/carboniferio/carbonifer/internal/estimate/coefficients"
+	"github.com/carboniferio/carbonifer/internal/estimate/estimation"
 	"github.com/carboniferio/carbonifer/internal/providers"
+	"github.com/carboniferio/carbonifer/internal/providers/aws"
+	"github.com/carboniferio/carbonifer/internal/providers/azure"
 	"github.com/carboniferio/carbonifer/internal/providers/gcp"
 	"github.com/carboniferio/carbonifer/internal/resources"
 	"github.com/shopspring/decimal"
+	log "github.com/sirupsen/logrus"
 	"github.com/spf13/viper"
 )
 
-func estimateWattCPU(resource *resources.ComputeResource) decimal.Decimal {
+// estimateWattCPU returns the average power of the CPUs and the power model used to compute it
+func estimateWattCPU(resource *resources.ComputeResource) (decimal.Decimal, string) {
 	provider := resource.Identification.Provider
 	// Get average CPU usage
 	averageCPUUse := decimal.NewFromFloat(viper.GetFloat64(fmt.Sprintf("provider.%s.avg_cpu_use", provider.String())))
 
 	var avgWatts decimal.Decimal
-	// Average Watts = Min Watts + Avg vCPU Utilization * (Max Watts - Min Watts)
+	cpuPowerModel := estimation.CPUPowerModelLinear
+	// If a power curve is known, Average Watts = Watts interpolated on the curve at Avg vCPU Utilization
+	// Otherwise Average Watts = Min Watts + Avg vCPU Utilization * (Max Watts - Min Watts)
 	cpuPlatform := resource.Specs.CPUType
-	if cpuPlatform != "" && resource.Identification.Provider == providers.GCP {
-		cpuPlatform := gcp.GetCPUWatt(strings.ToLower(cpuPlatform))
-		avgWatts = cpuPlatform.MinWatts.Add(averageCPUUse.Mul(cpuPlatform.MaxWatts.Sub(cpuPlatform.MinWatts)))
-	} else {
-		minWH := coefficients.GetEnergyCoefficients().GetByProvider(provider).CPUMinWh
-		maxWh := coefficients.GetEnergyCoefficients().GetByProvider(provider).CPUMaxWh
-		avgWatts = minWH.Add(averageCPUUse.Mul(maxWh.Sub(minWH)))
+	cpuPlatformFound := false
+	if cpuPlatform != "" {
+		if curve := providers.GetCPUPowerCurve(provider, cpuPlatform); curve != nil {
+			avgWatts = curve.WattsAt(averageCPUUse)
+			cpuPowerModel = estimation.CPUPowerModelCurve
+			cpuPlatformFound = true
+		} else if minWatts, maxWatts, ok := getCPUPlatformWatts(provider, cpuPlatform); ok {
+			avgWatts = minWatts.Add(averageCPUUse.Mul(maxWatts.Sub(minWatts)))
+			cpuPlatformFound = true
+		} else {
+			log.Debugf("Unknown CPU platform '%v' for %v, using provider average", cpuPlatform, provider)
+		}
+	}
+	if !cpuPlatformFound {
+		if curve := providers.GetCPUPowerCurve(provider, ""); curve != nil {
+			avgWatts = curve.WattsAt(averageCPUUse)
+			cpuPowerModel = estimation.CPUPowerModelCurve
+		} else {
+			minWH := coefficients.GetEnergyCoefficients().GetByProvider(provider).CPUMinWh
+			maxWh := coefficients.GetEnergyCoefficients().GetByProvider(provider).CPUMaxWh
+			avgWatts = minWH.Add(averageCPUUse.Mul(maxWh.Sub(minWH)))
+		}
+	}
+	return avgWatts.Mul(resource.Specs.GetVCPUs()), cpuPowerModel
+}
+
+// getCPUPlatformWatts returns the min and max watts of a CPU platform of a provider, if known
+func getCPUPlatformWatts(provider providers.Provider, cpuPlatform string) (decimal.Decimal, decimal.Decimal, bool) {
+	switch provider {
+	case providers.GCP:
+		cpuWatt := gcp.GetCPUWatt(strings.ToLower(cpuPlatform))
+		return cpuWatt.MinWatts, cpuWatt.MaxWatts, cpuWatt.Architecture != ""
+	case providers.AWS:
+		cpuWatt := aws.GetCPUWatt(cpuPlatform)
+		return cpuWatt.MinWatts, cpuWatt.MaxWatts, cpuWatt.Architecture != ""
+	case providers.AZURE:
+		cpuWatt := azure.GetCPUWatt(cpuPlatform)
+		return cpuWatt.MinWatts, cpuWatt.MaxWatts, cpuWatt.Architecture != ""
+	default:
+		return decimal.Zero, decimal.Zero, false
 	}
-	return avgWatts.Mul(decimal.NewFromInt32(resource.Specs.VCPUs))
 }
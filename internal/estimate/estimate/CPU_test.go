@@ -0,0 +1,80 @@
+package estimate
+
+import (
+	"testing"
+
+	"github.com/carboniferio/carbonifer/internal/estimate/estimation"
+	"github.com/carboniferio/carbonifer/internal/providers"
+	"github.com/carboniferio/carbonifer/internal/resources"
+	_ "github.com/carboniferio/carbonifer/internal/testutils"
+	"github.com/shopspring/decimal"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_estimateWattCPU(t *testing.T) {
+	viper.Set("provider.azure.avg_cpu_use", 0.5)
+	defer viper.Set("provider.azure.avg_cpu_use", nil)
+	type args struct {
+		resource *resources.ComputeResource
+	}
+	tests := []struct {
+		name      string
+		args      args
+		want      decimal.Decimal
+		wantModel string
+	}{
+		{
+			name: "GCP CPU platform without curve",
+			args: args{&resources.ComputeResource{
+				Identification: &resources.ResourceIdentification{Provider: providers.GCP},
+				Specs:          &resources.ComputeResourceSpecs{VCPUs: 2, CPUType: "Broadwell"},
+			}},
+			want:      decimal.RequireFromString("4.0985815294117648"),
+			wantModel: estimation.CPUPowerModelLinear,
+		},
+		{
+			name: "GCP CPU platform with curve",
+			args: args{&resources.ComputeResource{
+				Identification: &resources.ResourceIdentification{Provider: providers.GCP},
+				Specs:          &resources.ComputeResourceSpecs{VCPUs: 2, CPUType: "Cascade Lake"},
+			}},
+			want:      decimal.NewFromFloat(4.4),
+			wantModel: estimation.CPUPowerModelCurve,
+		},
+		{
+			name: "AWS CPU platform",
+			args: args{&resources.ComputeResource{
+				Identification: &resources.ResourceIdentification{Provider: providers.AWS},
+				Specs:          &resources.ComputeResourceSpecs{VCPUs: 2, CPUType: "Graviton3"},
+			}},
+			want:      decimal.RequireFromString("2.0494494467592593"),
+			wantModel: estimation.CPUPowerModelLinear,
+		},
+		{
+			name: "Unknown CPU platform",
+			args: args{&resources.ComputeResource{
+				Identification: &resources.ResourceIdentification{Provider: providers.AWS},
+				Specs:          &resources.ComputeResourceSpecs{VCPUs: 2, CPUType: "Unknown"},
+			}},
+			want:      decimal.RequireFromString("4.306"),
+			wantModel: estimation.CPUPowerModelLinear,
+		},
+		{
+			name: "Provider default curve",
+			args: args{&resources.ComputeResource{
+				Identification: &resources.ResourceIdentification{Provider: providers.AZURE},
+				Specs:          &resources.ComputeResourceSpecs{VCPUs: 4},
+			}},
+			want:      decimal.NewFromFloat(9.2),
+			wantModel: estimation.CPUPowerModelCurve,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, gotModel := estimateWattCPU(tt.args.resource)
+			assert.Equal(t, tt.want.String(), got.String())
+			assert.Equal(t, tt.wantModel, gotModel)
+		})
+	}
+}
@@ -1,37 +1,134 @@
 package estimate
 
 import (
+	"fmt"
+
 	"github.com/carboniferio/carbonifer/internal/estimate/coefficients"
+	"github.com/carboniferio/carbonifer/internal/estimate/estimation"
 	"github.com/carboniferio/carbonifer/internal/resources"
 	"github.com/shopspring/decimal"
 	log "github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
 )
 
+// energyEstimate is the energy used by a resource and how it has been estimated
+type energyEstimate struct {
+	WattHour decimal.Decimal
+	// ITWattHour is the energy used by the resource itself, without the data center overhead (PUE)
+	ITWattHour    decimal.Decimal
+	PUE           decimal.Decimal
+	CPUPowerModel string
+	// Schedule is the description of the running schedule of the compute part, empty if always running
+	Schedule string
+	// InstanceMix is the assumed mix of instance types of mixed instances
+	InstanceMix []estimation.InstanceShare
+}
+
 // Source: https://www.cloudcarbonfootprint.org/docs/methodology/#appendix-i-energy-coefficients
 // in Watt Hour
-func estimateWattHour(resource *resources.ComputeResource) decimal.Decimal {
-	cpuEstimationInWh := estimateWattCPU(resource)
-	log.Debugf("%v.%v CPU in Wh: %v", resource.Identification.ResourceType, resource.Identification.Name, cpuEstimationInWh)
-	memoryEstimationInWH := estimateWattMem(resource)
-	log.Debugf("%v.%v Memory in Wh: %v", resource.Identification.ResourceType, resource.Identification.Name, memoryEstimationInWH)
+func estimateWattHour(resource *resources.ComputeResource) energyEstimate {
+	if resource.PodRequests {
+		// Resources run the requests of their pods, declared in config or found in the plan
+		requestedResource := *resource
+		requestedResource.Specs = getRequestedSpecs(resource)
+		resource = &requestedResource
+	}
 	storageInWh := estimateWattStorage(resource)
 	log.Debugf("%v.%v Storage in Wh: %v", resource.Identification.ResourceType, resource.Identification.Name, storageInWh)
+	pue := coefficients.RegionPUE(resource.Identification.Provider, resource.Identification.Region)
+	log.Debugf("%v.%v PUE %v", resource.Identification.ResourceType, resource.Identification.Name, pue)
+	resourceSchedule := getSchedule(resource)
+	if resource.Serverless != nil {
+		// Serverless resources run when invoked, not on a schedule
+		resourceSchedule = getUsageSchedule(resource)
+	}
+
+	var rawWattEstimate decimal.Decimal
+	var cpuPowerModel string
+	instanceMix := getInstanceMix(resource)
+	if instanceMix == nil {
+		rawWattEstimate, cpuPowerModel = estimateWattInstance(resource, resourceSchedule, storageInWh)
+	} else {
+		// Mixed instances: the count is in capacity units, each share of the capacity runs instances of
+		// its type and lifecycle, of WeightedCapacity units each
+		rawWattEstimate = decimal.Zero
+		for i, share := range instanceMix {
+			shareResource := *resource
+			shareIdentification := *resource.Identification
+			shareIdentification.Lifecycle = share.Lifecycle
+			shareResource.Identification = &shareIdentification
+			shareResource.Specs = share.InstanceType.Specs
+			instanceWattEstimate, instanceCPUPowerModel := estimateWattInstance(&shareResource, resourceSchedule, storageInWh)
+			log.Debugf("%v.%v %v %v share %v in Wh: %v", resource.Identification.ResourceType, resource.Identification.Name, share.InstanceType.InstanceType, share.Lifecycle, share.Share, instanceWattEstimate)
+			rawWattEstimate = rawWattEstimate.Add(instanceWattEstimate.Mul(share.Share).Div(share.InstanceType.WeightedCapacity))
+			if i == 0 {
+				cpuPowerModel = instanceCPUPowerModel
+			}
+		}
+	}
+
+	replicationFactor := resource.Identification.ReplicationFactor
+	if replicationFactor == 0 {
+		replicationFactor = 1
+	}
+	itWattEstimate := rawWattEstimate.Mul(decimal.NewFromInt32(replicationFactor))
+	wattEstimate := pue.Mul(itWattEstimate)
+	log.Debugf("%v.%v Energy in Wh: %v", resource.Identification.ResourceType, resource.Identification.Name, wattEstimate)
+	return energyEstimate{
+		WattHour:      wattEstimate,
+		ITWattHour:    itWattEstimate,
+		PUE:           pue,
+		CPUPowerModel: cpuPowerModel,
+		Schedule:      resourceSchedule.description(),
+		InstanceMix:   getInstanceMixReport(instanceMix),
+	}
+}
+
+// estimateWattInstance returns the energy of an instance of the resource, before replication and PUE,
+// with the CPU power model used
+func estimateWattInstance(resource *resources.ComputeResource, resourceSchedule *schedule, storageInWh decimal.Decimal) (decimal.Decimal, string) {
+	if resource.FixedPower != nil {
+		// Hardware not known (like networking appliances), the power is taken from a fixed profile
+		fixedEstimationInWh := estimateWattFixed(resource)
+		log.Debugf("%v.%v Fixed profile in Wh: %v", resource.Identification.ResourceType, resource.Identification.Name, fixedEstimationInWh)
+		rawWattEstimate := fixedEstimationInWh.Mul(resourceSchedule.runningFraction()).Mul(getLifecycleUptime(resource)).Add(storageInWh)
+		return rawWattEstimate, ""
+	}
+
+	if resource.DedicatedHost != "" {
+		// Compute is counted in the dedicated host the resource is placed on, storage is not part of the host
+		log.Debugf("%v.%v Compute counted in dedicated host %v", resource.Identification.ResourceType, resource.Identification.Name, resource.DedicatedHost)
+		return storageInWh, ""
+	}
+
+	cpuEstimationInWh, cpuPowerModel := estimateWattCPU(resource)
+	log.Debugf("%v.%v CPU in Wh (%v model): %v", resource.Identification.ResourceType, resource.Identification.Name, cpuPowerModel, cpuEstimationInWh)
+	memoryEstimationInWH := estimateWattMem(resource)
+	log.Debugf("%v.%v Memory in Wh: %v", resource.Identification.ResourceType, resource.Identification.Name, memoryEstimationInWH)
 	gpuEstimationInWh := EstimateWattGPU(resource)
 	log.Debugf("%v.%v GPUs in Wh: %v", resource.Identification.ResourceType, resource.Identification.Name, gpuEstimationInWh)
-	pue := coefficients.GetEnergyCoefficients().GetByProvider(resource.Identification.Provider).PueAverage
 
-	log.Debugf("%v.%v PUE %v", resource.Identification.ResourceType, resource.Identification.Name, pue)
-	rawWattEstimate := decimal.Sum(
+	// Compute stops outside of its schedule and when reclaimed (spot, preemptible), storage keeps consuming energy
+	computeEstimationInWh := decimal.Sum(
 		cpuEstimationInWh,
 		memoryEstimationInWH,
-		storageInWh,
 		gpuEstimationInWh,
 	)
-	replicationFactor := resource.Identification.ReplicationFactor
-	if replicationFactor == 0 {
-		replicationFactor = 1
+	uptime := getLifecycleUptime(resource)
+	log.Debugf("%v.%v Uptime %v", resource.Identification.ResourceType, resource.Identification.Name, uptime)
+	rawWattEstimate := computeEstimationInWh.Mul(resourceSchedule.runningFraction()).Mul(uptime).Add(storageInWh)
+	return rawWattEstimate, cpuPowerModel
+}
+
+// getLifecycleUptime returns the expected fraction of time a resource is running, depending on its lifecycle (spot, preemptible...)
+func getLifecycleUptime(resource *resources.ComputeResource) decimal.Decimal {
+	lifecycle := resource.Identification.Lifecycle
+	if lifecycle == "" {
+		lifecycle = resources.LifecycleOnDemand
 	}
-	wattEstimate := pue.Mul(rawWattEstimate).Mul(decimal.NewFromInt32(replicationFactor))
-	log.Debugf("%v.%v Energy in Wh: %v", resource.Identification.ResourceType, resource.Identification.Name, wattEstimate)
-	return wattEstimate
+	uptimeKey := fmt.Sprintf("lifecycle.%s.uptime", lifecycle)
+	if !viper.IsSet(uptimeKey) {
+		return decimal.NewFromInt(1)
+	}
+	return decimal.NewFromFloat(viper.GetFloat64(uptimeKey))
 }
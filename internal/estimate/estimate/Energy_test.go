@@ -0,0 +1,149 @@
+package estimate
+
+import (
+	"testing"
+
+	"github.com/carboniferio/carbonifer/internal/providers"
+	"github.com/carboniferio/carbonifer/internal/resources"
+	_ "github.com/carboniferio/carbonifer/internal/testutils"
+	"github.com/shopspring/decimal"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_estimateWattHour_PUE(t *testing.T) {
+	tests := []struct {
+		name     string
+		provider providers.Provider
+		region   string
+		wantPUE  string
+	}{
+		{"GCP region with PUE", providers.GCP, "europe-west1", "1.09"},
+		{"GCP region without PUE", providers.GCP, "europe-west9", "1.16"},
+		{"AWS region with PUE", providers.AWS, "eu-west-1", "1.12"},
+		{"AWS region without PUE", providers.AWS, "eu-west-3", "1.1356"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resource := resources.ComputeResource{
+				Identification: &resources.ResourceIdentification{
+					Provider:          tt.provider,
+					Region:            tt.region,
+					ReplicationFactor: 1,
+				},
+				Specs: &resources.ComputeResourceSpecs{
+					VCPUs:    2,
+					MemoryMb: 4096,
+				},
+			}
+			got := estimateWattHour(&resource)
+			assert.Equal(t, tt.wantPUE, got.PUE.String())
+			assert.Equal(t, got.ITWattHour.Mul(got.PUE).String(), got.WattHour.String())
+		})
+	}
+}
+
+func Test_estimateWattHour_Lifecycle(t *testing.T) {
+	onDemand := resources.ComputeResource{
+		Identification: &resources.ResourceIdentification{
+			Provider:          providers.GCP,
+			Region:            "europe-west9",
+			ReplicationFactor: 1,
+		},
+		Specs: &resources.ComputeResourceSpecs{
+			VCPUs:      2,
+			MemoryMb:   4096,
+			SsdStorage: decimal.NewFromInt(100),
+		},
+	}
+	spot := onDemand
+	spot.Identification = &resources.ResourceIdentification{
+		Provider:          providers.GCP,
+		Region:            "europe-west9",
+		ReplicationFactor: 1,
+		Lifecycle:         resources.LifecycleSpot,
+	}
+
+	onDemandEnergy := estimateWattHour(&onDemand)
+	spotEnergy := estimateWattHour(&spot)
+	// Only compute is reclaimed, storage keeps consuming energy
+	storageWh := estimateWattStorage(&onDemand)
+	wantSpotITWattHour := onDemandEnergy.ITWattHour.Sub(storageWh).Mul(decimal.NewFromFloat(0.5)).Add(storageWh)
+	assert.Equal(t, wantSpotITWattHour.String(), spotEnergy.ITWattHour.String())
+
+	viper.Set("lifecycle.spot.uptime", 1)
+	defer viper.Set("lifecycle.spot.uptime", 0.5)
+	spotEnergy = estimateWattHour(&spot)
+	assert.Equal(t, onDemandEnergy.WattHour.String(), spotEnergy.WattHour.String())
+}
+
+func Test_estimateWattHour_StorageReplication(t *testing.T) {
+	notReplicated := resources.ComputeResource{
+		Identification: &resources.ResourceIdentification{
+			Provider:          providers.AWS,
+			Region:            "eu-west-3",
+			ReplicationFactor: 1,
+		},
+		Specs: &resources.ComputeResourceSpecs{
+			VCPUs:      2,
+			MemoryMb:   4096,
+			SsdStorage: decimal.NewFromInt(100),
+			HddStorage: decimal.NewFromInt(200),
+		},
+	}
+	replicatedSpecs := *notReplicated.Specs
+	replicatedSpecs.SsdStorageReplicationFactor = decimal.NewFromInt(2)
+	replicatedSpecs.HddStorageReplicationFactor = decimal.NewFromInt(2)
+	replicated := notReplicated
+	replicated.Specs = &replicatedSpecs
+
+	storageWh := estimateWattStorage(&notReplicated)
+	assert.Equal(t, storageWh.Mul(decimal.NewFromInt(2)).String(), estimateWattStorage(&replicated).String())
+
+	// Only storage is replicated, not CPU and memory
+	gotDiff := estimateWattHour(&replicated).ITWattHour.Sub(estimateWattHour(&notReplicated).ITWattHour)
+	assert.Equal(t, storageWh.String(), gotDiff.String())
+}
+
+func Test_estimateWattHour_FixedPower(t *testing.T) {
+	resource := resources.ComputeResource{
+		Identification: &resources.ResourceIdentification{
+			Provider:          providers.AZURE,
+			Region:            "westeurope",
+			ReplicationFactor: 1,
+		},
+		Specs: &resources.ComputeResourceSpecs{
+			HddStorage: decimal.Zero,
+			SsdStorage: decimal.Zero,
+		},
+		FixedPower: &resources.FixedPower{
+			Watts:         decimal.NewFromInt(10),
+			WattsPerUnit:  decimal.NewFromInt(15),
+			CapacityUnits: decimal.NewFromInt(6),
+		},
+	}
+	got := estimateWattHour(&resource)
+	assert.Equal(t, "100", got.ITWattHour.String())
+	assert.Equal(t, "", got.CPUPowerModel)
+}
+
+func Test_estimateWattHour_DedicatedHost(t *testing.T) {
+	resource := resources.ComputeResource{
+		Identification: &resources.ResourceIdentification{
+			Provider:          providers.AWS,
+			Region:            "eu-west-3",
+			ReplicationFactor: 1,
+		},
+		Specs: &resources.ComputeResourceSpecs{
+			VCPUs:      4,
+			MemoryMb:   16384,
+			HddStorage: decimal.Zero,
+			SsdStorage: decimal.NewFromInt(100),
+		},
+		DedicatedHost: "aws_ec2_host.dedicated",
+	}
+	got := estimateWattHour(&resource)
+	// Only the storage, the compute is counted in the host
+	assert.Equal(t, estimateWattStorage(&resource).String(), got.ITWattHour.String())
+	assert.True(t, got.ITWattHour.IsPositive())
+}
@@ -18,7 +18,8 @@ func EstimateSupportedResource(resource resources.Resource) *estimation.Estimati
 	var computeResource resources.ComputeResource = resource.(resources.ComputeResource)
 	// Electric power used per unit of time
 	// It's computed first in watt per hour
-	avgWattHour := estimateWattHour(&computeResource) // Watt hour
+	energy := estimateWattHour(&computeResource)
+	avgWattHour := energy.WattHour // Watt hour
 	avgKWattHour := avgWattHour.Div(decimal.NewFromInt(1000))
 
 	// Regional grid emission per unit of time
@@ -30,21 +31,15 @@ func EstimateSupportedResource(resource resources.Resource) *estimation.Estimati
 
 	// Carbon Emissions
 	carbonEmissionInGCO2PerH := avgKWattHour.Mul(regionEmissions.GridCarbonIntensity)
-	carbonEmissionPerTime := carbonEmissionInGCO2PerH
-	if strings.ToLower(viper.GetString("unit.time")) == "d" {
-		carbonEmissionPerTime = carbonEmissionPerTime.Mul(decimal.NewFromInt(24))
-	}
-	if strings.ToLower(viper.GetString("unit.time")) == "m" {
-		carbonEmissionPerTime = carbonEmissionPerTime.Mul(decimal.NewFromInt(24 * 30))
-	}
-	if strings.ToLower(viper.GetString("unit.time")) == "y" {
-		carbonEmissionPerTime = carbonEmissionPerTime.Mul(decimal.NewFromInt(24 * 365))
-	}
+	carbonEmissionPerTime := perTimeUnit(carbonEmissionInGCO2PerH)
 	if strings.ToLower(viper.GetString("unit.carbon")) == "kg" {
 		carbonEmissionPerTime = carbonEmissionPerTime.Div(decimal.NewFromInt(1000))
 	}
 	carbonEmissionPerTimeStr := carbonEmissionPerTime.String()
 
+	// Water used per unit of time, in litres
+	waterPerTime := perTimeUnit(estimateWaterLitreHour(&computeResource, energy))
+
 	log.Debugf(
 		"estimating resource %v.%v (%v): %v %v%v * %v %vCO2/%v%v = %v %vCO2/%v%v * %v = %v %vCO2/%v%v * %v",
 		computeResource.Identification.ResourceType,
@@ -80,8 +75,32 @@ func EstimateSupportedResource(resource resources.Resource) *estimation.Estimati
 		Resource:        &computeResource,
 		Power:           avgWattHour.RoundFloor(10),
 		CarbonEmissions: carbonEmissionPerTime.RoundFloor(10),
+		Water:           waterPerTime.RoundFloor(10),
 		AverageCPUUsage: decimal.NewFromFloat(viper.GetFloat64("provider.gcp.avg_cpu_use")).RoundFloor(10),
 		TotalCount:      decimal.NewFromInt(count * replicationFactor),
+		PUE:             energy.PUE,
+		CPUPowerModel:   energy.CPUPowerModel,
+		Schedule:        energy.Schedule,
+		InstanceMix:     energy.InstanceMix,
+	}
+	if computeResource.FixedPower != nil {
+		est.PowerProfile = estimation.PowerProfileFixed
 	}
 	return est
 }
+
+// perTimeUnit converts a value per hour to the configured time unit.
+// Values per hour are averaged over a week of the resource schedule, so that compute is only counted
+// during its running hours of the day, month or year, and storage all the time.
+func perTimeUnit(valuePerHour decimal.Decimal) decimal.Decimal {
+	switch strings.ToLower(viper.GetString("unit.time")) {
+	case "d":
+		return valuePerHour.Mul(decimal.NewFromInt(24))
+	case "m":
+		return valuePerHour.Mul(decimal.NewFromInt(24 * 30))
+	case "y":
+		return valuePerHour.Mul(decimal.NewFromInt(24 * 365))
+	default:
+		return valuePerHour
+	}
+}
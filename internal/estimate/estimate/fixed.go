@@ -0,0 +1,13 @@
+package estimate
+
+import (
+	"github.com/carboniferio/carbonifer/internal/resources"
+	"github.com/shopspring/decimal"
+)
+
+// estimateWattFixed returns the power of an instance of a resource estimated from a fixed profile,
+// in Watt Hour: its base power plus the power of its configured capacity units
+func estimateWattFixed(resource *resources.ComputeResource) decimal.Decimal {
+	fixedPower := resource.FixedPower
+	return fixedPower.Watts.Add(fixedPower.WattsPerUnit.Mul(fixedPower.CapacityUnits))
+}
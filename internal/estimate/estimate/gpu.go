@@ -7,9 +7,18 @@ import (
 	"github.com/carboniferio/carbonifer/internal/providers"
 	"github.com/carboniferio/carbonifer/internal/resources"
 	"github.com/shopspring/decimal"
+	log "github.com/sirupsen/logrus"
 	"github.com/spf13/viper"
 )
 
+// Fallbacks for GPUs missing from the GPU catalog, set by `gpu.unknown_fallback` config
+const (
+	// UnknownGPUFallbackLargest assumes the GPU with the largest known TDP
+	UnknownGPUFallbackLargest = "largest"
+	// UnknownGPUFallbackZero ignores the GPU
+	UnknownGPUFallbackZero = "zero"
+)
+
 // EstimateWattGPU estimates the power consumption of a GPU resource
 func EstimateWattGPU(resource *resources.ComputeResource) decimal.Decimal {
 	// Get average GPU usage
@@ -17,11 +26,34 @@ func EstimateWattGPU(resource *resources.ComputeResource) decimal.Decimal {
 	averageCPUUse := decimal.NewFromFloat(viper.GetFloat64(fmt.Sprintf("provider.%s.avg_gpu_use", provider)))
 
 	avgWattsTotal := decimal.Zero
-	// Average Watts = Min Watts + Avg GPU Utilization * (Max Watts - Min Watts)
+	// Average Watts = Min Watts + Avg GPU Utilization * (TDP - Min Watts)
 	for _, gpuType := range resource.Specs.GpuTypes {
-		gpuWatt := providers.GetGPUWatt(gpuType)
-		avgWatts := gpuWatt.MinWatts.Add(averageCPUUse.Mul(gpuWatt.MaxWatts.Sub(gpuWatt.MinWatts)))
+		gpuModel := providers.GetGPUModel(resource.Identification.Provider, gpuType)
+		if gpuModel == nil {
+			gpuModel = getUnknownGPUFallback(resource, gpuType)
+			if gpuModel == nil {
+				continue
+			}
+		}
+		avgWatts := gpuModel.MinWatts.Add(averageCPUUse.Mul(gpuModel.TDPWatts.Sub(gpuModel.MinWatts)))
 		avgWattsTotal = avgWattsTotal.Add(avgWatts)
 	}
 	return avgWattsTotal
 }
+
+// getUnknownGPUFallback returns the GPU model assumed for a GPU missing from the catalog, or nil to ignore it
+func getUnknownGPUFallback(resource *resources.ComputeResource, gpuType string) *providers.GPUModel {
+	fallback := viper.GetString("gpu.unknown_fallback")
+	switch fallback {
+	case UnknownGPUFallbackZero:
+		log.Warnf("%v: unknown GPU type '%v' for %v, its power is ignored", resource.GetAddress(), gpuType, resource.Identification.Provider)
+		return nil
+	case UnknownGPUFallbackLargest, "":
+		largest := providers.GetLargestGPUModel()
+		log.Warnf("%v: unknown GPU type '%v' for %v, assuming the largest known GPU (%v)", resource.GetAddress(), gpuType, resource.Identification.Provider, largest.Name)
+		return largest
+	default:
+		log.Fatalf("Invalid gpu.unknown_fallback '%v', must be '%v' or '%v'", fallback, UnknownGPUFallbackLargest, UnknownGPUFallbackZero)
+		return nil
+	}
+}
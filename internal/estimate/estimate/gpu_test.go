@@ -7,6 +7,7 @@ import (
 	"github.com/carboniferio/carbonifer/internal/resources"
 	_ "github.com/carboniferio/carbonifer/internal/testutils"
 	"github.com/shopspring/decimal"
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -35,6 +36,46 @@ var twoGPUResources = resources.ComputeResource{
 	},
 }
 
+var awsGPUResource = resources.ComputeResource{
+	Identification: &resources.ResourceIdentification{
+		Name:     "aws-gpu",
+		Count:    1,
+		Provider: providers.AWS,
+	},
+	Specs: &resources.ComputeResourceSpecs{
+		GpuTypes: []string{
+			"T4",
+			"T4",
+		},
+	},
+}
+
+var unknownGPUResource = resources.ComputeResource{
+	Identification: &resources.ResourceIdentification{
+		Name:     "unknown-gpu",
+		Count:    1,
+		Provider: providers.AWS,
+	},
+	Specs: &resources.ComputeResourceSpecs{
+		GpuTypes: []string{
+			"Gaudi HL-205",
+		},
+	},
+}
+
+var tpuResource = resources.ComputeResource{
+	Identification: &resources.ResourceIdentification{
+		Name:     "tpu",
+		Count:    4,
+		Provider: providers.GCP,
+	},
+	Specs: &resources.ComputeResourceSpecs{
+		GpuTypes: []string{
+			"tpu-v4",
+		},
+	},
+}
+
 func Test_estimateWattGPU(t *testing.T) {
 	type args struct {
 		resource *resources.ComputeResource
@@ -54,6 +95,21 @@ func Test_estimateWattGPU(t *testing.T) {
 			args: args{&twoGPUResources},
 			want: decimal.New(2660, -1),
 		},
+		{
+			name: "AWS GPU names",
+			args: args{&awsGPUResource},
+			want: decimal.New(790, -1),
+		},
+		{
+			name: "TPU chip",
+			args: args{&tpuResource},
+			want: decimal.New(1080, -1),
+		},
+		{
+			name: "Unknown GPU as largest",
+			args: args{&unknownGPUResource},
+			want: decimal.New(2265, -1),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -63,3 +119,11 @@ func Test_estimateWattGPU(t *testing.T) {
 		})
 	}
 }
+
+func Test_estimateWattGPU_UnknownFallbackZero(t *testing.T) {
+	viper.Set("gpu.unknown_fallback", UnknownGPUFallbackZero)
+	defer viper.Set("gpu.unknown_fallback", UnknownGPUFallbackLargest)
+
+	got := EstimateWattGPU(&unknownGPUResource)
+	assert.Equal(t, decimal.Zero, got)
+}
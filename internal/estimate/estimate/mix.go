@@ -0,0 +1,134 @@
+package estimate
+
+import (
+	"strings"
+
+	"github.com/carboniferio/carbonifer/internal/estimate/estimation"
+	"github.com/carboniferio/carbonifer/internal/resources"
+	"github.com/shopspring/decimal"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+)
+
+// instanceDistributionConfig is a distribution of instance types declared in `instance_distributions` config
+type instanceDistributionConfig struct {
+	// Target is "*" (all resources), a module ("module.dev") or a resource address ("aws_autoscaling_group.foo")
+	Target string `mapstructure:"target"`
+	// Distribution is the relative weight of each instance type in the capacity, like {"m5.large": 3, "m5a.large": 1}
+	Distribution map[string]float64 `mapstructure:"distribution"`
+}
+
+// instanceShare is the share of the capacity of mixed instances running an instance type with a lifecycle
+type instanceShare struct {
+	InstanceType *resources.MixedInstanceType
+	Lifecycle    string
+	// Share is the fraction of the capacity
+	Share decimal.Decimal
+}
+
+// getInstanceMix returns the assumed shares of the capacity of mixed instances per instance type and lifecycle,
+// or nil if the resource has a single instance type
+func getInstanceMix(resource *resources.ComputeResource) []instanceShare {
+	mixedInstances := resource.MixedInstances
+	if mixedInstances == nil || len(mixedInstances.InstanceTypes) == 0 {
+		return nil
+	}
+
+	weights := getInstanceTypeWeights(resource)
+	totalWeight := decimal.Sum(decimal.Zero, weights...)
+	onDemandFraction := getOnDemandFraction(resource.Identification.Count, mixedInstances)
+
+	shares := []instanceShare{}
+	for i := range mixedInstances.InstanceTypes {
+		if weights[i].IsZero() {
+			continue
+		}
+		typeFraction := weights[i].Div(totalWeight)
+		lifecycleFractions := []struct {
+			lifecycle string
+			fraction  decimal.Decimal
+		}{
+			{resources.LifecycleOnDemand, onDemandFraction},
+			{resources.LifecycleSpot, decimal.NewFromInt(1).Sub(onDemandFraction)},
+		}
+		for _, lifecycleFraction := range lifecycleFractions {
+			if lifecycleFraction.fraction.IsZero() {
+				continue
+			}
+			shares = append(shares, instanceShare{
+				InstanceType: &mixedInstances.InstanceTypes[i],
+				Lifecycle:    lifecycleFraction.lifecycle,
+				Share:        typeFraction.Mul(lifecycleFraction.fraction),
+			})
+		}
+	}
+	return shares
+}
+
+// getInstanceTypeWeights returns the weight of each instance type of mixed instances, from the most specific target
+// of `instance_distributions` config, or equal weights if none of the instance types is distributed
+func getInstanceTypeWeights(resource *resources.ComputeResource) []decimal.Decimal {
+	var distributionsConfig []instanceDistributionConfig
+	if err := viper.UnmarshalKey("instance_distributions", &distributionsConfig); err != nil {
+		log.Fatalf("Cannot read instance distributions config: %v", err)
+	}
+
+	var matchingConfig *instanceDistributionConfig
+	matchingPriority := -1
+	for i, config := range distributionsConfig {
+		priority := targetPriority(config.Target, resource.GetAddress())
+		if priority > matchingPriority {
+			matchingConfig = &distributionsConfig[i]
+			matchingPriority = priority
+		}
+	}
+
+	instanceTypes := resource.MixedInstances.InstanceTypes
+	weights := make([]decimal.Decimal, len(instanceTypes))
+	distributed := false
+	if matchingConfig != nil {
+		for i, instanceType := range instanceTypes {
+			weight, ok := matchingConfig.Distribution[strings.ToLower(instanceType.InstanceType)]
+			if ok && weight > 0 {
+				weights[i] = decimal.NewFromFloat(weight)
+				distributed = true
+			}
+		}
+	}
+	if !distributed {
+		for i := range weights {
+			weights[i] = decimal.NewFromInt(1)
+		}
+	}
+	return weights
+}
+
+// getOnDemandFraction returns the fraction of the capacity fulfilled by on-demand instances, the others being spot
+func getOnDemandFraction(capacity int64, mixedInstances *resources.MixedInstances) decimal.Decimal {
+	percentageAboveBase := mixedInstances.OnDemandPercentageAboveBaseCapacity.Div(decimal.NewFromInt(100))
+	if capacity <= 0 {
+		return percentageAboveBase
+	}
+	baseCapacity := mixedInstances.OnDemandBaseCapacity
+	if baseCapacity >= capacity {
+		return decimal.NewFromInt(1)
+	}
+	onDemandCapacity := decimal.NewFromInt(baseCapacity).Add(decimal.NewFromInt(capacity - baseCapacity).Mul(percentageAboveBase))
+	return onDemandCapacity.Div(decimal.NewFromInt(capacity))
+}
+
+// getInstanceMixReport returns the assumed mix of instance types to report
+func getInstanceMixReport(shares []instanceShare) []estimation.InstanceShare {
+	if len(shares) == 0 {
+		return nil
+	}
+	report := []estimation.InstanceShare{}
+	for _, share := range shares {
+		report = append(report, estimation.InstanceShare{
+			InstanceType: share.InstanceType.InstanceType,
+			Lifecycle:    share.Lifecycle,
+			Share:        share.Share.RoundFloor(4),
+		})
+	}
+	return report
+}
@@ -0,0 +1,147 @@
+package estimate
+
+import (
+	"testing"
+
+	"github.com/carboniferio/carbonifer/internal/estimate/estimation"
+	"github.com/carboniferio/carbonifer/internal/providers"
+	"github.com/carboniferio/carbonifer/internal/resources"
+	_ "github.com/carboniferio/carbonifer/internal/testutils"
+	"github.com/shopspring/decimal"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func mixedInstancesResource(count int64, onDemandBase int64, onDemandPercentage int64, instanceTypes ...resources.MixedInstanceType) resources.ComputeResource {
+	return resources.ComputeResource{
+		Identification: &resources.ResourceIdentification{
+			Address:           "aws_autoscaling_group.web",
+			Provider:          providers.AWS,
+			Region:            "eu-west-3",
+			Count:             count,
+			ReplicationFactor: 1,
+		},
+		Specs: &resources.ComputeResourceSpecs{
+			SsdStorage: decimal.NewFromInt(20),
+		},
+		MixedInstances: &resources.MixedInstances{
+			InstanceTypes:                       instanceTypes,
+			OnDemandBaseCapacity:                onDemandBase,
+			OnDemandPercentageAboveBaseCapacity: decimal.NewFromInt(onDemandPercentage),
+		},
+	}
+}
+
+func mixedInstanceType(name string, weightedCapacity int64, vcpus int32, memoryMb int32) resources.MixedInstanceType {
+	return resources.MixedInstanceType{
+		InstanceType:     name,
+		WeightedCapacity: decimal.NewFromInt(weightedCapacity),
+		Specs: &resources.ComputeResourceSpecs{
+			VCPUs:    vcpus,
+			MemoryMb: memoryMb,
+			CPUType:  "Skylake",
+		},
+	}
+}
+
+func Test_getInstanceMix(t *testing.T) {
+	m5 := mixedInstanceType("m5.large", 1, 2, 8192)
+	m5a := mixedInstanceType("m5a.large", 1, 2, 8192)
+
+	tests := []struct {
+		name         string
+		resource     resources.ComputeResource
+		distribution map[string]interface{}
+		want         []estimation.InstanceShare
+	}{
+		{
+			name:     "equal weights, on-demand",
+			resource: mixedInstancesResource(4, 0, 100, m5, m5a),
+			want: []estimation.InstanceShare{
+				{InstanceType: "m5.large", Lifecycle: resources.LifecycleOnDemand, Share: decimal.RequireFromString("0.5")},
+				{InstanceType: "m5a.large", Lifecycle: resources.LifecycleOnDemand, Share: decimal.RequireFromString("0.5")},
+			},
+		},
+		{
+			name:     "on-demand base and spot above",
+			resource: mixedInstancesResource(4, 1, 0, m5, m5a),
+			want: []estimation.InstanceShare{
+				{InstanceType: "m5.large", Lifecycle: resources.LifecycleOnDemand, Share: decimal.RequireFromString("0.125")},
+				{InstanceType: "m5.large", Lifecycle: resources.LifecycleSpot, Share: decimal.RequireFromString("0.375")},
+				{InstanceType: "m5a.large", Lifecycle: resources.LifecycleOnDemand, Share: decimal.RequireFromString("0.125")},
+				{InstanceType: "m5a.large", Lifecycle: resources.LifecycleSpot, Share: decimal.RequireFromString("0.375")},
+			},
+		},
+		{
+			name:         "configured distribution",
+			resource:     mixedInstancesResource(4, 0, 100, m5, m5a),
+			distribution: map[string]interface{}{"m5.large": 3, "m5a.large": 1},
+			want: []estimation.InstanceShare{
+				{InstanceType: "m5.large", Lifecycle: resources.LifecycleOnDemand, Share: decimal.RequireFromString("0.75")},
+				{InstanceType: "m5a.large", Lifecycle: resources.LifecycleOnDemand, Share: decimal.RequireFromString("0.25")},
+			},
+		},
+		{
+			name:         "distribution of other instance types",
+			resource:     mixedInstancesResource(4, 0, 100, m5, m5a),
+			distribution: map[string]interface{}{"c5.large": 1},
+			want: []estimation.InstanceShare{
+				{InstanceType: "m5.large", Lifecycle: resources.LifecycleOnDemand, Share: decimal.RequireFromString("0.5")},
+				{InstanceType: "m5a.large", Lifecycle: resources.LifecycleOnDemand, Share: decimal.RequireFromString("0.5")},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.distribution != nil {
+				viper.Set("instance_distributions", []map[string]interface{}{
+					{"target": "aws_autoscaling_group.web", "distribution": tt.distribution},
+				})
+				defer viper.Set("instance_distributions", nil)
+			}
+			got := getInstanceMixReport(getInstanceMix(&tt.resource))
+			assert.Len(t, got, len(tt.want))
+			for i := range tt.want {
+				assert.Equal(t, tt.want[i].InstanceType, got[i].InstanceType)
+				assert.Equal(t, tt.want[i].Lifecycle, got[i].Lifecycle)
+				assert.Equal(t, tt.want[i].Share.String(), got[i].Share.String())
+			}
+		})
+	}
+}
+
+func Test_estimateWattHour_MixedInstances(t *testing.T) {
+	single := resources.ComputeResource{
+		Identification: &resources.ResourceIdentification{
+			Provider:          providers.AWS,
+			Region:            "eu-west-3",
+			Count:             4,
+			ReplicationFactor: 1,
+		},
+		Specs: &resources.ComputeResourceSpecs{
+			VCPUs:      2,
+			MemoryMb:   8192,
+			CPUType:    "Skylake",
+			SsdStorage: decimal.NewFromInt(20),
+		},
+	}
+	singleEnergy := estimateWattHour(&single)
+
+	// A mix of a single instance type is the same as this instance type
+	mixed := mixedInstancesResource(4, 0, 100, mixedInstanceType("m5.large", 1, 2, 8192))
+	mixedEnergy := estimateWattHour(&mixed)
+	assert.Equal(t, singleEnergy.WattHour.String(), mixedEnergy.WattHour.String())
+	assert.Len(t, mixedEnergy.InstanceMix, 1)
+
+	// An instance of 2 capacity units counts for half a capacity unit each
+	weighted := mixedInstancesResource(4, 0, 100, mixedInstanceType("m5.large", 2, 2, 8192))
+	assert.Equal(t, singleEnergy.WattHour.Div(decimal.NewFromInt(2)).Round(10).String(), estimateWattHour(&weighted).WattHour.Round(10).String())
+
+	// Spot instances use the spot uptime
+	singleSpot := single
+	singleSpotIdentification := *single.Identification
+	singleSpotIdentification.Lifecycle = resources.LifecycleSpot
+	singleSpot.Identification = &singleSpotIdentification
+	spot := mixedInstancesResource(4, 0, 0, mixedInstanceType("m5.large", 1, 2, 8192))
+	assert.Equal(t, estimateWattHour(&singleSpot).WattHour.String(), estimateWattHour(&spot).WattHour.String())
+}
@@ -0,0 +1,200 @@
+package estimate
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	// Timezones of schedules are checked even if the system has no timezone database
+	_ "time/tzdata"
+
+	"github.com/carboniferio/carbonifer/internal/resources"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+)
+
+const hoursPerWeek = 7 * 24
+
+// scheduleConfig is a schedule declared in `schedules` config
+type scheduleConfig struct {
+	// Target is "*" (all resources), a module ("module.dev") or a resource address ("aws_instance.foo")
+	Target string `mapstructure:"target"`
+	// Schedule is a weekly time window, like "weekdays 08:00-20:00 Europe/Paris"
+	Schedule string `mapstructure:"schedule"`
+	// HoursPerWeek is the number of hours the resource runs per week
+	HoursPerWeek *float64 `mapstructure:"hours_per_week"`
+}
+
+// schedule is the running time of the compute part of a resource
+type schedule struct {
+	Description string
+	// RunningHoursPerWeek are instance hours for serverless resources, that can run several instances at the same time
+	RunningHoursPerWeek decimal.Decimal
+}
+
+var weekDays = []string{"mon", "tue", "wed", "thu", "fri", "sat", "sun"}
+
+var scheduleRegex = regexp.MustCompile(`^(\S+)\s+(\d{1,2}):(\d{2})\s*[-–]\s*(\d{1,2}):(\d{2})(?:\s+(\S+))?$`)
+
+// runningFraction returns the fraction of time the compute part of the resource runs
+func (s *schedule) runningFraction() decimal.Decimal {
+	if s == nil {
+		return decimal.NewFromInt(1)
+	}
+	return s.RunningHoursPerWeek.Div(decimal.NewFromInt(hoursPerWeek))
+}
+
+// description returns the description of the schedule, empty if always running
+func (s *schedule) description() string {
+	if s == nil {
+		return ""
+	}
+	return s.Description
+}
+
+// getSchedule returns the schedule of a resource, from the most specific target of `schedules` config
+// (resource address, then module, then "*"), or nil if the resource runs all the time
+func getSchedule(resource *resources.ComputeResource) *schedule {
+	var schedulesConfig []scheduleConfig
+	if err := viper.UnmarshalKey("schedules", &schedulesConfig); err != nil {
+		log.Fatalf("Cannot read schedules config: %v", err)
+	}
+
+	var matchingConfig *scheduleConfig
+	matchingPriority := -1
+	for i, config := range schedulesConfig {
+		priority := targetPriority(config.Target, resource.GetAddress())
+		if priority > matchingPriority {
+			matchingConfig = &schedulesConfig[i]
+			matchingPriority = priority
+		}
+	}
+	if matchingConfig == nil {
+		return nil
+	}
+
+	resourceSchedule, err := parseSchedule(*matchingConfig)
+	if err != nil {
+		log.Fatalf("Invalid schedule for '%v': %v", matchingConfig.Target, err)
+	}
+	log.Debugf("%v runs %v (%v hours per week)", resource.GetAddress(), resourceSchedule.Description, resourceSchedule.RunningHoursPerWeek)
+	return resourceSchedule
+}
+
+// targetPriority returns how specific a config target (schedule, instance distribution) is for a resource address, -1 if it doesn't match
+func targetPriority(target string, address string) int {
+	switch {
+	case target == "*":
+		return 0
+	case address == target || strings.HasPrefix(address, target+"["):
+		// Resource address, with or without index
+		return 1 << 16
+	case strings.HasPrefix(target, "module.") && strings.HasPrefix(address, target+"."):
+		// Module, the deepest one is the most specific
+		return len(target)
+	default:
+		return -1
+	}
+}
+
+func parseSchedule(config scheduleConfig) (*schedule, error) {
+	if config.HoursPerWeek != nil {
+		if *config.HoursPerWeek < 0 || *config.HoursPerWeek > hoursPerWeek {
+			return nil, errors.Errorf("hours_per_week must be between 0 and %v: %v", hoursPerWeek, *config.HoursPerWeek)
+		}
+		return &schedule{
+			Description:         fmt.Sprintf("%v hours per week", *config.HoursPerWeek),
+			RunningHoursPerWeek: decimal.NewFromFloat(*config.HoursPerWeek),
+		}, nil
+	}
+
+	matches := scheduleRegex.FindStringSubmatch(strings.TrimSpace(config.Schedule))
+	if matches == nil {
+		return nil, errors.Errorf("schedule must be like 'weekdays 08:00-20:00 Europe/Paris': '%v'", config.Schedule)
+	}
+	days, err := parseScheduleDays(matches[1])
+	if err != nil {
+		return nil, err
+	}
+	from, err := parseScheduleTime(matches[2], matches[3])
+	if err != nil {
+		return nil, err
+	}
+	to, err := parseScheduleTime(matches[4], matches[5])
+	if err != nil {
+		return nil, err
+	}
+	if matches[6] != "" {
+		// The timezone doesn't change the number of running hours, but must be valid
+		if _, err := time.LoadLocation(matches[6]); err != nil {
+			return nil, errors.Wrapf(err, "invalid timezone")
+		}
+	}
+
+	// A window ending before it starts goes over midnight
+	minutesPerDay := to - from
+	if minutesPerDay <= 0 {
+		minutesPerDay += 24 * 60
+	}
+	return &schedule{
+		Description:         config.Schedule,
+		RunningHoursPerWeek: decimal.NewFromInt(int64(days * minutesPerDay)).Div(decimal.NewFromInt(60)),
+	}, nil
+}
+
+// parseScheduleDays returns the number of days per week of "weekdays", "weekends", "everyday", "mon-fri" or "mon,wed,fri"
+func parseScheduleDays(days string) (int, error) {
+	switch strings.ToLower(days) {
+	case "weekdays":
+		return 5, nil
+	case "weekends":
+		return 2, nil
+	case "everyday", "daily":
+		return 7, nil
+	}
+	count := 0
+	for _, dayRange := range strings.Split(strings.ToLower(days), ",") {
+		bounds := strings.Split(dayRange, "-")
+		if len(bounds) > 2 {
+			return 0, errors.Errorf("invalid days '%v'", dayRange)
+		}
+		first := weekDayIndex(bounds[0])
+		last := weekDayIndex(bounds[len(bounds)-1])
+		if first < 0 || last < 0 || last < first {
+			return 0, errors.Errorf("invalid days '%v'", dayRange)
+		}
+		count += last - first + 1
+	}
+	if count > 7 {
+		return 0, errors.Errorf("days overlap: '%v'", days)
+	}
+	return count, nil
+}
+
+func weekDayIndex(day string) int {
+	for i, weekDay := range weekDays {
+		if strings.HasPrefix(strings.TrimSpace(day), weekDay) {
+			return i
+		}
+	}
+	return -1
+}
+
+// parseScheduleTime returns the minutes since midnight of a time of day
+func parseScheduleTime(hours string, minutes string) (int, error) {
+	h, err := strconv.Atoi(hours)
+	if err != nil {
+		return 0, errors.Wrapf(err, "invalid time")
+	}
+	m, err := strconv.Atoi(minutes)
+	if err != nil {
+		return 0, errors.Wrapf(err, "invalid time")
+	}
+	if h > 24 || m > 59 || (h == 24 && m > 0) {
+		return 0, errors.Errorf("invalid time %v:%v", hours, minutes)
+	}
+	return h*60 + m, nil
+}
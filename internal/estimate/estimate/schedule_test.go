@@ -0,0 +1,104 @@
+package estimate
+
+import (
+	"testing"
+
+	"github.com/carboniferio/carbonifer/internal/providers"
+	"github.com/carboniferio/carbonifer/internal/resources"
+	_ "github.com/carboniferio/carbonifer/internal/testutils"
+	"github.com/shopspring/decimal"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_parseSchedule(t *testing.T) {
+	sixty := 60.0
+	tests := []struct {
+		name    string
+		config  scheduleConfig
+		want    string
+		wantErr bool
+	}{
+		{"hours per week", scheduleConfig{HoursPerWeek: &sixty}, "60", false},
+		{"weekdays", scheduleConfig{Schedule: "weekdays 08:00-20:00 Europe/Paris"}, "60", false},
+		{"en dash", scheduleConfig{Schedule: "weekdays 08:00–20:00"}, "60", false},
+		{"day range", scheduleConfig{Schedule: "mon-thu 9:00-17:30"}, "34", false},
+		{"day list", scheduleConfig{Schedule: "mon,wed,sat-sun 00:00-24:00"}, "96", false},
+		{"over midnight", scheduleConfig{Schedule: "everyday 22:00-06:00 UTC"}, "56", false},
+		{"unknown day", scheduleConfig{Schedule: "workdays 08:00-20:00"}, "", true},
+		{"unknown timezone", scheduleConfig{Schedule: "weekdays 08:00-20:00 Mars/Olympus"}, "", true},
+		{"invalid time", scheduleConfig{Schedule: "weekdays 08:00-25:00"}, "", true},
+		{"malformed", scheduleConfig{Schedule: "office hours"}, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseSchedule(tt.config)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got.RunningHoursPerWeek.String())
+		})
+	}
+}
+
+func Test_getSchedule(t *testing.T) {
+	viper.Set("schedules", []map[string]interface{}{
+		{"target": "*", "hours_per_week": 100},
+		{"target": "module.dev", "schedule": "weekdays 08:00-20:00 Europe/Paris"},
+		{"target": "module.dev.module.db", "hours_per_week": 40},
+		{"target": "aws_instance.batch", "hours_per_week": 10},
+	})
+	defer viper.Set("schedules", nil)
+
+	tests := []struct {
+		address string
+		want    string
+	}{
+		{"aws_instance.foo", "100 hours per week"},
+		{"aws_instance.batch", "10 hours per week"},
+		{"aws_instance.batch[1]", "10 hours per week"},
+		{"aws_instance.batch2", "100 hours per week"},
+		{"module.dev.aws_instance.foo", "weekdays 08:00-20:00 Europe/Paris"},
+		{"module.dev.module.db.aws_db_instance.foo", "40 hours per week"},
+		{"module.development.aws_instance.foo", "100 hours per week"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.address, func(t *testing.T) {
+			resource := resources.ComputeResource{
+				Identification: &resources.ResourceIdentification{Address: tt.address},
+			}
+			assert.Equal(t, tt.want, getSchedule(&resource).description())
+		})
+	}
+}
+
+func Test_estimateWattHour_Schedule(t *testing.T) {
+	resource := resources.ComputeResource{
+		Identification: &resources.ResourceIdentification{
+			Address:           "google_compute_instance.dev",
+			Provider:          providers.GCP,
+			Region:            "europe-west9",
+			ReplicationFactor: 1,
+		},
+		Specs: &resources.ComputeResourceSpecs{
+			VCPUs:      2,
+			MemoryMb:   4096,
+			HddStorage: decimal.NewFromInt(1024),
+		},
+	}
+	alwaysOn := estimateWattHour(&resource)
+
+	viper.Set("schedules", []map[string]interface{}{
+		{"target": "google_compute_instance.dev", "hours_per_week": 42},
+	})
+	defer viper.Set("schedules", nil)
+	scheduled := estimateWattHour(&resource)
+
+	// Compute runs a quarter of the time, storage all the time
+	storage := estimateWattStorage(&resource).Mul(scheduled.PUE)
+	compute := alwaysOn.WattHour.Sub(storage)
+	assert.Equal(t, compute.Div(decimal.NewFromInt(4)).Add(storage).String(), scheduled.WattHour.String())
+	assert.Equal(t, "42 hours per week", scheduled.Schedule)
+}
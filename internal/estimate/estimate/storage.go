@@ -10,7 +10,35 @@ func estimateWattStorage(resource *resources.ComputeResource) decimal.Decimal {
 	provider := resource.Identification.Provider
 	storageSsdWhGb := coefficients.GetEnergyCoefficients().GetByProvider(provider).StorageSsdWhTb.Div(decimal.NewFromInt32(1024))
 	storageHddWhGb := coefficients.GetEnergyCoefficients().GetByProvider(provider).StorageHddWhTb.Div(decimal.NewFromInt32(1024))
-	storageSSDWh := resource.Specs.SsdStorage.Mul(storageSsdWhGb)
-	storageHddWh := resource.Specs.HddStorage.Mul(storageHddWhGb)
+	ssdStorage := resource.Specs.SsdStorage
+	hddStorage := resource.Specs.HddStorage
+	if resource.ObjectStorage {
+		// Buckets are HDD storage of the size of their usage, cluster volumes (like Aurora) SSD storage
+		if isSsdObjectStorage(resource) {
+			ssdStorage = getStoredGb(resource)
+		} else {
+			hddStorage = getStoredGb(resource)
+		}
+	}
+	storageSSDWh := ssdStorage.Mul(storageReplicationFactor(resource.Specs.SsdStorageReplicationFactor)).Mul(storageSsdWhGb)
+	storageHddWh := hddStorage.Mul(storageReplicationFactor(resource.Specs.HddStorageReplicationFactor)).Mul(storageHddWhGb)
 	return storageSSDWh.Add(storageHddWh)
 }
+
+// storageReplicationFactor returns the replication factor of the storage, 1 if not replicated
+func storageReplicationFactor(replicationFactor decimal.Decimal) decimal.Decimal {
+	if replicationFactor.IsZero() {
+		return decimal.NewFromInt(1)
+	}
+	return replicationFactor
+}
+
+// isSsdObjectStorage returns true if the data of an object storage is stored on SSD, which is known from its
+// SSD size or replication factor when its size comes from usage
+func isSsdObjectStorage(resource *resources.ComputeResource) bool {
+	if resource.Specs.SsdStorage.IsPositive() {
+		return true
+	}
+	return resource.Specs.HddStorage.IsZero() && resource.Specs.HddStorageReplicationFactor.IsZero() &&
+		!resource.Specs.SsdStorageReplicationFactor.IsZero()
+}
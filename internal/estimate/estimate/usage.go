@@ -0,0 +1,153 @@
+package estimate
+
+import (
+	"fmt"
+
+	"github.com/carboniferio/carbonifer/internal/resources"
+	"github.com/shopspring/decimal"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+)
+
+const hoursPerMonth = 30 * 24
+
+// usageConfig is the usage of a serverless resource, of a bucket or of pods declared in `usages` config
+type usageConfig struct {
+	// Target is "*" (all resources), a module ("module.dev") or a resource address ("aws_lambda_function.foo")
+	Target string `mapstructure:"target"`
+	// InvocationsPerMonth is the number of invocations (or requests) per month
+	InvocationsPerMonth *float64 `mapstructure:"invocations_per_month"`
+	// AvgDurationMs is the average duration of an invocation, in milliseconds
+	AvgDurationMs *float64 `mapstructure:"avg_duration_ms"`
+	// StoredGb is the size of the data stored in a bucket, in GB
+	StoredGb *float64 `mapstructure:"stored_gb"`
+	// VCPUs is the total of the vCPUs requested by the pods of a cluster
+	VCPUs *float64 `mapstructure:"vcpus"`
+	// MemoryGb is the total of the memory requested by the pods of a cluster, in GB
+	MemoryGb *float64 `mapstructure:"memory_gb"`
+}
+
+// NeedsUsage returns true if the resource is serverless, a bucket or sized by its pods and cannot be estimated
+// without a declared usage
+func NeedsUsage(resource *resources.ComputeResource) bool {
+	if resource.Serverless != nil {
+		return getUsageSchedule(resource) == nil
+	}
+	if resource.ObjectStorage {
+		return getStoredGb(resource).IsZero()
+	}
+	if resource.PodRequests {
+		return getRequestedSpecs(resource).GetVCPUs().IsZero()
+	}
+	return false
+}
+
+// getUsageConfig returns the usage of a resource from the most specific target of `usages` config
+// declaring it, or nil
+func getUsageConfig(resource *resources.ComputeResource, declared func(config usageConfig) bool) *usageConfig {
+	var usagesConfig []usageConfig
+	if err := viper.UnmarshalKey("usages", &usagesConfig); err != nil {
+		log.Fatalf("Cannot read usages config: %v", err)
+	}
+
+	var matchingConfig *usageConfig
+	matchingPriority := -1
+	for i, config := range usagesConfig {
+		if !declared(config) {
+			continue
+		}
+		priority := targetPriority(config.Target, resource.GetAddress())
+		if priority > matchingPriority {
+			matchingConfig = &usagesConfig[i]
+			matchingPriority = priority
+		}
+	}
+	return matchingConfig
+}
+
+// getUsageSchedule returns the running time of the instances of a serverless resource, as a schedule of instance
+// hours per week (more than the hours of a week if several instances run at the same time): its min instances
+// running all the time, or the instances running the invocations of `usages` config if more.
+// Nil if the resource has no min instances and no declared usage
+func getUsageSchedule(resource *resources.ComputeResource) *schedule {
+	matchingConfig := getUsageConfig(resource, func(config usageConfig) bool {
+		return config.InvocationsPerMonth != nil && config.AvgDurationMs != nil
+	})
+
+	minInstances := decimal.NewFromInt(resource.Serverless.MinInstances)
+	if matchingConfig == nil {
+		if !minInstances.IsPositive() {
+			return nil
+		}
+		return &schedule{
+			Description:         fmt.Sprintf("min %v instances", minInstances),
+			RunningHoursPerWeek: minInstances.Mul(decimal.NewFromInt(hoursPerWeek)),
+		}
+	}
+	invocationsPerMonth := decimal.NewFromFloat(*matchingConfig.InvocationsPerMonth)
+	avgDurationMs := decimal.NewFromFloat(*matchingConfig.AvgDurationMs)
+	if invocationsPerMonth.IsNegative() || avgDurationMs.IsNegative() {
+		log.Fatalf("Invalid usage for '%v': invocations_per_month and avg_duration_ms must be positive", matchingConfig.Target)
+	}
+
+	// Instances handle Concurrency invocations at the same time
+	invocationHoursPerMonth := invocationsPerMonth.
+		Mul(avgDurationMs).
+		Div(decimal.NewFromInt(3600 * 1000)).
+		Div(decimal.NewFromInt(resource.Serverless.Concurrency))
+	instances := decimal.Max(minInstances, invocationHoursPerMonth.Div(decimal.NewFromInt(hoursPerMonth)))
+
+	description := fmt.Sprintf("%v invocations per month of %v ms", invocationsPerMonth, avgDurationMs)
+	if minInstances.IsPositive() {
+		description = fmt.Sprintf("%v, min %v instances", description, minInstances)
+	}
+	log.Debugf("%v runs %v (%v instances on average)", resource.GetAddress(), description, instances)
+	return &schedule{
+		Description:         description,
+		RunningHoursPerWeek: instances.Mul(decimal.NewFromInt(hoursPerWeek)),
+	}
+}
+
+// getStoredGb returns the size of the data stored in a bucket or a cluster volume, from `usages` config, or from the plan
+// (tags/labels) if not declared in config
+func getStoredGb(resource *resources.ComputeResource) decimal.Decimal {
+	matchingConfig := getUsageConfig(resource, func(config usageConfig) bool {
+		return config.StoredGb != nil
+	})
+	if matchingConfig == nil {
+		return resource.Specs.HddStorage.Add(resource.Specs.SsdStorage)
+	}
+	storedGb := decimal.NewFromFloat(*matchingConfig.StoredGb)
+	if storedGb.IsNegative() {
+		log.Fatalf("Invalid usage for '%v': stored_gb must be positive", matchingConfig.Target)
+	}
+	log.Debugf("%v stores %v GB", resource.GetAddress(), storedGb)
+	return storedGb
+}
+
+// getRequestedSpecs returns the specs of a resource sized by the requests of its pods, from `usages` config,
+// or from the plan (workloads of the plan) if not declared in config
+func getRequestedSpecs(resource *resources.ComputeResource) *resources.ComputeResourceSpecs {
+	matchingConfig := getUsageConfig(resource, func(config usageConfig) bool {
+		return config.VCPUs != nil || config.MemoryGb != nil
+	})
+	if matchingConfig == nil {
+		return resource.Specs
+	}
+	specs := *resource.Specs
+	if matchingConfig.VCPUs != nil {
+		if *matchingConfig.VCPUs < 0 {
+			log.Fatalf("Invalid usage for '%v': vcpus must be positive", matchingConfig.Target)
+		}
+		specs.VCPUs = 0
+		specs.FractionalVCPUs = decimal.NewFromFloat(*matchingConfig.VCPUs)
+	}
+	if matchingConfig.MemoryGb != nil {
+		if *matchingConfig.MemoryGb < 0 {
+			log.Fatalf("Invalid usage for '%v': memory_gb must be positive", matchingConfig.Target)
+		}
+		specs.MemoryMb = int32(*matchingConfig.MemoryGb * 1024)
+	}
+	log.Debugf("%v requests %v vCPUs and %v MB", resource.GetAddress(), specs.GetVCPUs(), specs.MemoryMb)
+	return &specs
+}
@@ -0,0 +1,219 @@
+package estimate
+
+import (
+	"testing"
+
+	"github.com/carboniferio/carbonifer/internal/providers"
+	"github.com/carboniferio/carbonifer/internal/resources"
+	_ "github.com/carboniferio/carbonifer/internal/testutils"
+	"github.com/shopspring/decimal"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func serverlessResource(address string, minInstances int64, concurrency int64) resources.ComputeResource {
+	return resources.ComputeResource{
+		Identification: &resources.ResourceIdentification{
+			Address:           address,
+			Provider:          providers.AWS,
+			Region:            "eu-west-3",
+			Count:             1,
+			ReplicationFactor: 1,
+		},
+		Specs: &resources.ComputeResourceSpecs{
+			FractionalVCPUs: decimal.NewFromInt(1),
+			MemoryMb:        1769,
+		},
+		Serverless: &resources.Serverless{
+			MinInstances: minInstances,
+			Concurrency:  concurrency,
+		},
+	}
+}
+
+func Test_getUsageSchedule(t *testing.T) {
+	viper.Set("usages", []map[string]interface{}{
+		// 720 hours of invocations per month
+		{"target": "aws_lambda_function.api", "invocations_per_month": 2592000, "avg_duration_ms": 1000},
+		{"target": "google_cloud_run_v2_service.web", "invocations_per_month": 2592000, "avg_duration_ms": 1000},
+	})
+	defer viper.Set("usages", nil)
+
+	tests := []struct {
+		name      string
+		resource  resources.ComputeResource
+		want      string
+		wantUsage bool
+	}{
+		{"declared usage", serverlessResource("aws_lambda_function.api", 0, 1), "168", true},
+		{"min instances above usage", serverlessResource("aws_lambda_function.api", 2, 1), "336", true},
+		{"concurrency", serverlessResource("google_cloud_run_v2_service.web", 0, 80), "2.1", true},
+		{"min instances only", serverlessResource("aws_lambda_function.warm", 1, 1), "168", true},
+		{"needs usage", serverlessResource("aws_lambda_function.other", 0, 1), "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := getUsageSchedule(&tt.resource)
+			assert.Equal(t, !tt.wantUsage, NeedsUsage(&tt.resource))
+			if !tt.wantUsage {
+				assert.Nil(t, got)
+				return
+			}
+			assert.Equal(t, tt.want, got.RunningHoursPerWeek.String())
+		})
+	}
+}
+
+func Test_estimateWattHour_Serverless(t *testing.T) {
+	viper.Set("usages", []map[string]interface{}{
+		{"target": "aws_lambda_function.api", "invocations_per_month": 2592000, "avg_duration_ms": 500},
+	})
+	defer viper.Set("usages", nil)
+
+	// Half of the time running is half of the energy of an instance running all the time
+	serverless := serverlessResource("aws_lambda_function.api", 0, 1)
+	instance := serverlessResource("aws_lambda_function.api", 0, 1)
+	instance.Serverless = nil
+	assert.Equal(t,
+		estimateWattHour(&instance).WattHour.Div(decimal.NewFromInt(2)).Round(10).String(),
+		estimateWattHour(&serverless).WattHour.Round(10).String(),
+	)
+	assert.Equal(t, "2592000 invocations per month of 500 ms", estimateWattHour(&serverless).Schedule)
+}
+
+func bucketResource(address string, taggedGb int64) resources.ComputeResource {
+	return resources.ComputeResource{
+		Identification: &resources.ResourceIdentification{
+			Address:           address,
+			Provider:          providers.AWS,
+			Region:            "eu-west-3",
+			Count:             1,
+			ReplicationFactor: 1,
+		},
+		Specs: &resources.ComputeResourceSpecs{
+			HddStorage:                  decimal.NewFromInt(taggedGb),
+			HddStorageReplicationFactor: decimal.NewFromInt(3),
+		},
+		ObjectStorage: true,
+	}
+}
+
+func Test_getStoredGb(t *testing.T) {
+	viper.Set("usages", []map[string]interface{}{
+		{"target": "aws_s3_bucket.data", "stored_gb": 1024},
+		// Invocations don't apply to buckets
+		{"target": "*", "invocations_per_month": 1000, "avg_duration_ms": 100},
+	})
+	defer viper.Set("usages", nil)
+
+	tests := []struct {
+		name      string
+		resource  resources.ComputeResource
+		want      string
+		wantUsage bool
+	}{
+		{"declared usage", bucketResource("aws_s3_bucket.data", 0), "1024", true},
+		{"declared usage over tags", bucketResource("aws_s3_bucket.data", 10), "1024", true},
+		{"tags", bucketResource("aws_s3_bucket.logs", 10), "10", true},
+		{"needs usage", bucketResource("aws_s3_bucket.other", 0), "0", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, getStoredGb(&tt.resource).String())
+			assert.Equal(t, !tt.wantUsage, NeedsUsage(&tt.resource))
+		})
+	}
+}
+
+func Test_estimateWattStorage_Bucket(t *testing.T) {
+	viper.Set("usages", []map[string]interface{}{
+		{"target": "aws_s3_bucket.data", "stored_gb": 1024},
+	})
+	defer viper.Set("usages", nil)
+
+	// Same energy as 1024 GB of HDD, stored 3 times
+	bucket := bucketResource("aws_s3_bucket.data", 0)
+	disk := bucketResource("aws_s3_bucket.data", 1024)
+	disk.ObjectStorage = false
+	assert.Equal(t, estimateWattStorage(&disk).String(), estimateWattStorage(&bucket).String())
+	assert.True(t, estimateWattStorage(&bucket).IsPositive())
+}
+
+func Test_estimateWattStorage_ClusterVolume(t *testing.T) {
+	viper.Set("usages", []map[string]interface{}{
+		{"target": "aws_rds_cluster.aurora", "stored_gb": 100},
+	})
+	defer viper.Set("usages", nil)
+
+	// Same energy as 100 GB of SSD, stored 6 times
+	volume := resources.ComputeResource{
+		Identification: &resources.ResourceIdentification{
+			Address:  "aws_rds_cluster.aurora",
+			Provider: providers.AWS,
+			Region:   "eu-west-3",
+			Count:    1,
+		},
+		Specs: &resources.ComputeResourceSpecs{
+			HddStorage:                  decimal.Zero,
+			SsdStorage:                  decimal.Zero,
+			SsdStorageReplicationFactor: decimal.NewFromInt(6),
+		},
+		ObjectStorage: true,
+	}
+	disk := volume
+	disk.Specs = &resources.ComputeResourceSpecs{
+		HddStorage:                  decimal.Zero,
+		SsdStorage:                  decimal.NewFromInt(100),
+		SsdStorageReplicationFactor: decimal.NewFromInt(6),
+	}
+	disk.ObjectStorage = false
+	assert.False(t, NeedsUsage(&volume))
+	assert.Equal(t, estimateWattStorage(&disk).String(), estimateWattStorage(&volume).String())
+	assert.True(t, estimateWattStorage(&volume).IsPositive())
+}
+
+func autopilotResource(address string, vcpus decimal.Decimal, memoryMb int32) resources.ComputeResource {
+	return resources.ComputeResource{
+		Identification: &resources.ResourceIdentification{
+			Address:           address,
+			Provider:          providers.GCP,
+			Region:            "europe-west9",
+			Count:             1,
+			ReplicationFactor: 1,
+		},
+		Specs: &resources.ComputeResourceSpecs{
+			FractionalVCPUs: vcpus,
+			MemoryMb:        memoryMb,
+		},
+		PodRequests: true,
+	}
+}
+
+func Test_getRequestedSpecs(t *testing.T) {
+	viper.Set("usages", []map[string]interface{}{
+		{"target": "google_container_cluster.declared", "vcpus": 8, "memory_gb": 32},
+		{"target": "google_container_cluster.declared_vcpus", "vcpus": 2.5},
+	})
+	defer viper.Set("usages", nil)
+
+	tests := []struct {
+		name         string
+		resource     resources.ComputeResource
+		wantVCPUs    string
+		wantMemoryMb int32
+		wantUsage    bool
+	}{
+		{"declared usage over plan", autopilotResource("google_container_cluster.declared", decimal.NewFromInt(1), 1024), "8", 32768, true},
+		{"declared vCPUs only", autopilotResource("google_container_cluster.declared_vcpus", decimal.NewFromInt(1), 1024), "2.5", 1024, true},
+		{"plan", autopilotResource("google_container_cluster.plan", decimal.RequireFromString("3.25"), 11776), "3.25", 11776, true},
+		{"needs usage", autopilotResource("google_container_cluster.other", decimal.Zero, 0), "0", 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := getRequestedSpecs(&tt.resource)
+			assert.Equal(t, tt.wantVCPUs, got.GetVCPUs().String())
+			assert.Equal(t, tt.wantMemoryMb, got.MemoryMb)
+			assert.Equal(t, !tt.wantUsage, NeedsUsage(&tt.resource))
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package estimate
+
+import (
+	"github.com/carboniferio/carbonifer/internal/estimate/coefficients"
+	"github.com/carboniferio/carbonifer/internal/resources"
+	"github.com/shopspring/decimal"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+)
+
+// estimateWaterLitreHour returns the water used per hour by a resource, in litres
+func estimateWaterLitreHour(resource *resources.ComputeResource, energy energyEstimate) decimal.Decimal {
+	waterCoefficients := coefficients.GetWaterCoefficients().GetByProvider(resource.Identification.Provider)
+	region := resource.Identification.Region
+
+	// On-site water (cooling) = IT Energy (kWh) x WUE (L/kWh)
+	wue := waterCoefficients.WUE(region)
+	waterEstimate := energy.ITWattHour.Div(decimal.NewFromInt(1000)).Mul(wue)
+	log.Debugf("%v.%v On-site water in L/h (WUE %v): %v", resource.Identification.ResourceType, resource.Identification.Name, wue, waterEstimate)
+
+	// Off-site water (electricity generation) = Energy (kWh) x Grid Water Intensity (L/kWh)
+	if viper.GetBool("water.off_site") {
+		gridWaterIntensity := waterCoefficients.GridWaterIntensity(region)
+		offSiteWater := energy.WattHour.Div(decimal.NewFromInt(1000)).Mul(gridWaterIntensity)
+		log.Debugf("%v.%v Off-site water in L/h (grid water intensity %v): %v", resource.Identification.ResourceType, resource.Identification.Name, gridWaterIntensity, offSiteWater)
+		waterEstimate = waterEstimate.Add(offSiteWater)
+	}
+	return waterEstimate
+}
@@ -0,0 +1,44 @@
+package estimate
+
+import (
+	"testing"
+
+	"github.com/carboniferio/carbonifer/internal/providers"
+	"github.com/carboniferio/carbonifer/internal/resources"
+	_ "github.com/carboniferio/carbonifer/internal/testutils"
+	"github.com/shopspring/decimal"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_estimateWaterLitreHour(t *testing.T) {
+	energy := energyEstimate{
+		WattHour:   decimal.NewFromInt(1160),
+		ITWattHour: decimal.NewFromInt(1000),
+	}
+	tests := []struct {
+		name    string
+		region  string
+		offSite bool
+		want    string
+	}{
+		{"region WUE", "europe-west9", false, "0.5"},
+		{"average WUE", "us-central1", false, "0.97"},
+		{"region WUE and grid water intensity", "europe-west9", true, "2.82"},
+		{"average WUE and grid water intensity", "us-central1", true, "3.058"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			viper.Set("water.off_site", tt.offSite)
+			defer viper.Set("water.off_site", false)
+			resource := resources.ComputeResource{
+				Identification: &resources.ResourceIdentification{
+					Provider: providers.GCP,
+					Region:   tt.region,
+				},
+			}
+			got := estimateWaterLitreHour(&resource, energy)
+			assert.Equal(t, tt.want, got.String())
+		})
+	}
+}
@@ -0,0 +1,187 @@
+package estimate
+
+import (
+	"sort"
+
+	"github.com/carboniferio/carbonifer/internal/estimate/estimation"
+	"github.com/carboniferio/carbonifer/internal/resources"
+	"github.com/shopspring/decimal"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+)
+
+// hostGroup is the estimated resources running the workloads of a host: the host itself, and its node pools
+// if it is a Kubernetes cluster
+type hostGroup struct {
+	VCPUs    decimal.Decimal
+	MemoryMb decimal.Decimal
+	// Nodes is the number of instances of the resources of the group
+	Nodes decimal.Decimal
+	// Power, CarbonEmissions and Water are the ones of all the instances of the resources of the group
+	Power           decimal.Decimal
+	CarbonEmissions decimal.Decimal
+	Water           decimal.Decimal
+}
+
+// Allocations of the VMs placed on dedicated hosts, set by `dedicated_hosts.allocation` config
+const (
+	// DedicatedHostAllocationShare allocates to VMs their share of the vCPUs or memory of their host
+	DedicatedHostAllocationShare = "share"
+	// DedicatedHostAllocationZero allocates nothing to VMs, their host is counted whatever runs on it
+	DedicatedHostAllocationZero = "zero"
+)
+
+// GetDedicatedHostWorkload returns a VM placed on a dedicated host (or a sole-tenant node group) as a workload of
+// its host, or nil if the VM is not placed on a dedicated host or is not allocated a share of it
+func GetDedicatedHostWorkload(resource *resources.ComputeResource) *resources.WorkloadResource {
+	if resource.DedicatedHost == "" {
+		return nil
+	}
+	allocation := viper.GetString("dedicated_hosts.allocation")
+	switch allocation {
+	case DedicatedHostAllocationZero:
+		return nil
+	case DedicatedHostAllocationShare, "":
+		return &resources.WorkloadResource{
+			Identification: resource.Identification,
+			HostAddress:    resource.DedicatedHost,
+			VCPUs:          resource.Specs.GetVCPUs(),
+			MemoryMb:       decimal.NewFromInt32(resource.Specs.MemoryMb),
+		}
+	default:
+		log.Fatalf("Invalid dedicated_hosts.allocation '%v', must be '%v' or '%v'", allocation, DedicatedHostAllocationShare, DedicatedHostAllocationZero)
+		return nil
+	}
+}
+
+// AllocateWorkloads returns the share of the estimations of their hosts allocated to workloads, by their share of
+// the reserved vCPUs or memory of the host (the largest), the share of the hosts not allocated to any workload,
+// and the workloads whose host has not been estimated
+func AllocateWorkloads(workloads []resources.WorkloadResource, hosts []estimation.EstimationResource) ([]estimation.EstimationAllocation, []estimation.EstimationIdleCapacity, []resources.Resource) {
+	hostGroups := getHostGroups(hosts)
+
+	sort.Slice(workloads, func(i, j int) bool {
+		return workloads[i].GetAddress() < workloads[j].GetAddress()
+	})
+	var allocations []estimation.EstimationAllocation
+	var unallocated []resources.Resource
+	totalSharePerHost := map[string]decimal.Decimal{}
+	for _, workload := range workloads {
+		group, ok := hostGroups[workload.HostAddress]
+		if !ok {
+			log.Warnf("Host '%v' of %v has not been estimated", workload.HostAddress, workload.GetAddress())
+			unallocated = append(unallocated, workload)
+			continue
+		}
+		share := getWorkloadShare(&workload, group)
+		totalSharePerHost[workload.HostAddress] = totalSharePerHost[workload.HostAddress].Add(share)
+		allocations = append(allocations, estimation.EstimationAllocation{
+			Resource:    workload,
+			HostAddress: workload.HostAddress,
+			Share:       share,
+		})
+	}
+
+	for i, allocation := range allocations {
+		// Workloads cannot use more than their host, their shares are reduced if the host is overcommitted
+		if totalShare := totalSharePerHost[allocation.HostAddress]; totalShare.GreaterThan(decimal.NewFromInt(1)) {
+			allocation.Share = allocation.Share.Div(totalShare)
+		}
+		group := hostGroups[allocation.HostAddress]
+		allocation.Power = group.Power.Mul(allocation.Share).RoundFloor(10)
+		allocation.CarbonEmissions = group.CarbonEmissions.Mul(allocation.Share).RoundFloor(10)
+		allocation.Water = group.Water.Mul(allocation.Share).RoundFloor(10)
+		allocation.Share = allocation.Share.RoundFloor(4)
+		allocations[i] = allocation
+	}
+
+	// The remaining share of the hosts of workloads is idle
+	var idleCapacities []estimation.EstimationIdleCapacity
+	for hostAddress, totalShare := range totalSharePerHost {
+		idleShare := decimal.NewFromInt(1).Sub(totalShare)
+		if !idleShare.IsPositive() {
+			continue
+		}
+		group := hostGroups[hostAddress]
+		idleCapacities = append(idleCapacities, estimation.EstimationIdleCapacity{
+			HostAddress:     hostAddress,
+			Share:           idleShare.RoundFloor(4),
+			Power:           group.Power.Mul(idleShare).RoundFloor(10),
+			CarbonEmissions: group.CarbonEmissions.Mul(idleShare).RoundFloor(10),
+			Water:           group.Water.Mul(idleShare).RoundFloor(10),
+		})
+	}
+	sort.Slice(idleCapacities, func(i, j int) bool {
+		return idleCapacities[i].HostAddress < idleCapacities[j].HostAddress
+	})
+	return allocations, idleCapacities, unallocated
+}
+
+// getHostGroups returns the resources able to run workloads, by address of host: the address of the resource,
+// and the address of its cluster for node pools
+func getHostGroups(hosts []estimation.EstimationResource) map[string]*hostGroup {
+	hostGroups := map[string]*hostGroup{}
+	for _, host := range hosts {
+		hostResource, ok := host.Resource.(*resources.ComputeResource)
+		if !ok {
+			continue
+		}
+		hostAddresses := []string{hostResource.GetAddress()}
+		if hostResource.Cluster != "" && hostResource.Cluster != hostResource.GetAddress() {
+			hostAddresses = append(hostAddresses, hostResource.Cluster)
+		}
+		vcpus, memoryMb := getHostCapacity(hostResource)
+		for _, hostAddress := range hostAddresses {
+			group, ok := hostGroups[hostAddress]
+			if !ok {
+				group = &hostGroup{}
+				hostGroups[hostAddress] = group
+			}
+			group.VCPUs = group.VCPUs.Add(vcpus)
+			group.MemoryMb = group.MemoryMb.Add(memoryMb)
+			group.Nodes = group.Nodes.Add(host.TotalCount)
+			group.Power = group.Power.Add(host.Power.Mul(host.TotalCount))
+			group.CarbonEmissions = group.CarbonEmissions.Add(host.CarbonEmissions.Mul(host.TotalCount))
+			group.Water = group.Water.Add(host.Water.Mul(host.TotalCount))
+		}
+	}
+	return hostGroups
+}
+
+// getWorkloadShare returns the share of the host reserved by all the copies of a workload
+func getWorkloadShare(workload *resources.WorkloadResource, group *hostGroup) decimal.Decimal {
+	count := decimal.NewFromInt(workload.Identification.Count)
+	if workload.PerNode {
+		count = group.Nodes
+	}
+	share := decimal.Zero
+	if group.VCPUs.IsPositive() {
+		share = decimal.Max(share, workload.VCPUs.Mul(count).Div(group.VCPUs))
+	}
+	if group.MemoryMb.IsPositive() {
+		share = decimal.Max(share, workload.MemoryMb.Mul(count).Div(group.MemoryMb))
+	}
+	return decimal.Min(share, decimal.NewFromInt(1))
+}
+
+// getHostCapacity returns the vCPUs and memory of all the instances of a host
+func getHostCapacity(host *resources.ComputeResource) (decimal.Decimal, decimal.Decimal) {
+	count := decimal.NewFromInt(host.Identification.Count)
+	if host.Identification.ReplicationFactor > 1 {
+		// Instances replicated in several zones (like a GKE node pool)
+		count = count.Mul(decimal.NewFromInt32(host.Identification.ReplicationFactor))
+	}
+	instanceMix := getInstanceMix(host)
+	if instanceMix == nil {
+		return host.Specs.GetVCPUs().Mul(count), decimal.NewFromInt32(host.Specs.MemoryMb).Mul(count)
+	}
+	// Mixed instances: count is in capacity units
+	vcpus := decimal.Zero
+	memoryMb := decimal.Zero
+	for _, share := range instanceMix {
+		instancesPerUnit := share.Share.Div(share.InstanceType.WeightedCapacity)
+		vcpus = vcpus.Add(share.InstanceType.Specs.GetVCPUs().Mul(instancesPerUnit))
+		memoryMb = memoryMb.Add(decimal.NewFromInt32(share.InstanceType.Specs.MemoryMb).Mul(instancesPerUnit))
+	}
+	return vcpus.Mul(count), memoryMb.Mul(count)
+}
@@ -0,0 +1,193 @@
+package estimate
+
+import (
+	"testing"
+
+	"github.com/carboniferio/carbonifer/internal/estimate/estimation"
+	"github.com/carboniferio/carbonifer/internal/providers"
+	"github.com/carboniferio/carbonifer/internal/resources"
+	_ "github.com/carboniferio/carbonifer/internal/testutils"
+	"github.com/shopspring/decimal"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func workloadResource(name string, hostAddress string, count int64, vcpus float64, memoryMb int64) resources.WorkloadResource {
+	return resources.WorkloadResource{
+		Identification: &resources.ResourceIdentification{
+			Address:      "aws_ecs_service." + name,
+			Name:         name,
+			ResourceType: "aws_ecs_service",
+			Provider:     providers.AWS,
+			Region:       "eu-west-3",
+			Count:        count,
+		},
+		HostAddress: hostAddress,
+		VCPUs:       decimal.NewFromFloat(vcpus),
+		MemoryMb:    decimal.NewFromInt(memoryMb),
+	}
+}
+
+func TestAllocateWorkloads(t *testing.T) {
+	host := resources.ComputeResource{
+		Identification: &resources.ResourceIdentification{
+			Address:  "aws_autoscaling_group.ecs",
+			Provider: providers.AWS,
+			Region:   "eu-west-3",
+			Count:    2,
+		},
+		Specs: &resources.ComputeResourceSpecs{
+			VCPUs:    4,
+			MemoryMb: 16384,
+		},
+	}
+	hosts := []estimation.EstimationResource{
+		{
+			Resource:        &host,
+			Power:           decimal.NewFromInt(100),
+			CarbonEmissions: decimal.NewFromInt(10),
+			Water:           decimal.NewFromInt(1),
+			TotalCount:      decimal.NewFromInt(2),
+		},
+	}
+
+	tests := []struct {
+		name            string
+		workloads       []resources.WorkloadResource
+		wantShares      map[string]decimal.Decimal
+		wantIdleShare   string
+		wantUnallocated int
+	}{
+		{
+			name: "largest of vCPUs and memory share",
+			workloads: []resources.WorkloadResource{
+				// 2 x 1 vCPU of 8 vCPUs, 2 x 8 GB of 32 GB
+				workloadResource("api", "aws_autoscaling_group.ecs", 2, 1, 8192),
+				// 1 x 2 vCPUs of 8 vCPUs, 1 x 1 GB of 32 GB
+				workloadResource("worker", "aws_autoscaling_group.ecs", 1, 2, 1024),
+			},
+			wantShares: map[string]decimal.Decimal{
+				"aws_ecs_service.api":    decimal.RequireFromString("0.5"),
+				"aws_ecs_service.worker": decimal.RequireFromString("0.25"),
+			},
+			wantIdleShare: "0.25",
+		},
+		{
+			name: "overcommitted host",
+			workloads: []resources.WorkloadResource{
+				workloadResource("api", "aws_autoscaling_group.ecs", 4, 2, 1024),
+				workloadResource("worker", "aws_autoscaling_group.ecs", 2, 4, 1024),
+			},
+			wantShares: map[string]decimal.Decimal{
+				"aws_ecs_service.api":    decimal.RequireFromString("0.5"),
+				"aws_ecs_service.worker": decimal.RequireFromString("0.5"),
+			},
+		},
+		{
+			name: "unknown host",
+			workloads: []resources.WorkloadResource{
+				workloadResource("api", "aws_autoscaling_group.unknown", 1, 1, 1024),
+			},
+			wantShares:      map[string]decimal.Decimal{},
+			wantUnallocated: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			allocations, idleCapacities, unallocated := AllocateWorkloads(tt.workloads, hosts)
+			assert.Len(t, unallocated, tt.wantUnallocated)
+			if tt.wantIdleShare == "" {
+				assert.Empty(t, idleCapacities)
+			} else {
+				assert.Len(t, idleCapacities, 1)
+				assert.Equal(t, tt.wantIdleShare, idleCapacities[0].Share.String())
+				assert.Equal(t, decimal.NewFromInt(20).Mul(decimal.RequireFromString(tt.wantIdleShare)).String(), idleCapacities[0].CarbonEmissions.String())
+			}
+			assert.Len(t, allocations, len(tt.wantShares))
+			for _, allocation := range allocations {
+				wantShare := tt.wantShares[allocation.Resource.GetAddress()]
+				assert.Equal(t, wantShare.String(), allocation.Share.String())
+				assert.Equal(t, decimal.NewFromInt(200).Mul(wantShare).String(), allocation.Power.String())
+				assert.Equal(t, decimal.NewFromInt(20).Mul(wantShare).String(), allocation.CarbonEmissions.String())
+				assert.Equal(t, decimal.NewFromInt(2).Mul(wantShare).String(), allocation.Water.String())
+			}
+		})
+	}
+}
+
+func TestAllocateWorkloads_Cluster(t *testing.T) {
+	// 3 nodes of 2 vCPUs and 8 GB (1 per zone), and a node pool of 2 nodes of 4 vCPUs and 16 GB
+	cluster := resources.ComputeResource{
+		Identification: &resources.ResourceIdentification{
+			Address:           "google_container_cluster.main",
+			Provider:          providers.GCP,
+			Region:            "europe-west9",
+			Count:             1,
+			ReplicationFactor: 3,
+		},
+		Specs: &resources.ComputeResourceSpecs{VCPUs: 2, MemoryMb: 8192},
+	}
+	nodePool := resources.ComputeResource{
+		Identification: &resources.ResourceIdentification{
+			Address:           "google_container_node_pool.large",
+			Provider:          providers.GCP,
+			Region:            "europe-west9",
+			Count:             2,
+			ReplicationFactor: 1,
+		},
+		Specs:   &resources.ComputeResourceSpecs{VCPUs: 4, MemoryMb: 16384},
+		Cluster: "google_container_cluster.main",
+	}
+	hosts := []estimation.EstimationResource{
+		{Resource: &cluster, Power: decimal.NewFromInt(100), CarbonEmissions: decimal.NewFromInt(10), Water: decimal.NewFromInt(1), TotalCount: decimal.NewFromInt(3)},
+		{Resource: &nodePool, Power: decimal.NewFromInt(200), CarbonEmissions: decimal.NewFromInt(20), Water: decimal.NewFromInt(2), TotalCount: decimal.NewFromInt(2)},
+	}
+	web := workloadResource("web", "google_container_cluster.main", 7, 1, 1024)
+	web.Namespace = "shop"
+	// One copy on each of the 5 nodes
+	agent := workloadResource("agent", "google_container_cluster.main", 1, 0.2, 512)
+	agent.Namespace = "monitoring"
+	agent.PerNode = true
+
+	allocations, idleCapacities, unallocated := AllocateWorkloads([]resources.WorkloadResource{web, agent}, hosts)
+	assert.Empty(t, unallocated)
+	assert.Len(t, allocations, 2)
+	// 1 vCPU of 14 vCPUs
+	assert.Equal(t, "0.0714", allocations[0].Share.String())
+	// 7 vCPUs of 14 vCPUs, of the emissions of the 5 nodes
+	assert.Equal(t, "0.5", allocations[1].Share.String())
+	assert.Equal(t, "350", allocations[1].Power.String())
+	assert.Equal(t, "35", allocations[1].CarbonEmissions.String())
+	assert.Len(t, idleCapacities, 1)
+	assert.Equal(t, "google_container_cluster.main", idleCapacities[0].HostAddress)
+	assert.Equal(t, "0.4285", idleCapacities[0].Share.String())
+}
+
+func TestGetDedicatedHostWorkload(t *testing.T) {
+	vm := resources.ComputeResource{
+		Identification: &resources.ResourceIdentification{
+			Address:  "aws_instance.on_host",
+			Provider: providers.AWS,
+			Region:   "eu-west-3",
+			Count:    1,
+		},
+		Specs: &resources.ComputeResourceSpecs{
+			VCPUs:    4,
+			MemoryMb: 16384,
+		},
+		DedicatedHost: "aws_ec2_host.dedicated",
+	}
+
+	workload := GetDedicatedHostWorkload(&vm)
+	assert.NotNil(t, workload)
+	assert.Equal(t, "aws_ec2_host.dedicated", workload.HostAddress)
+	assert.Equal(t, "4", workload.VCPUs.String())
+	assert.Equal(t, "16384", workload.MemoryMb.String())
+
+	viper.Set("dedicated_hosts.allocation", DedicatedHostAllocationZero)
+	defer viper.Set("dedicated_hosts.allocation", DedicatedHostAllocationShare)
+	assert.Nil(t, GetDedicatedHostWorkload(&vm))
+
+	vm.DedicatedHost = ""
+	assert.Nil(t, GetDedicatedHostWorkload(&vm))
+}
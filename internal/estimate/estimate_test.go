@@ -48,12 +48,28 @@ var resourceGCPComputeCPUType = resources.ComputeResource{
 	},
 }
 
+var resourceAzureComputeBasic = resources.ComputeResource{
+	Identification: &resources.ResourceIdentification{
+		Address:           "azurerm_linux_virtual_machine.machine-name-4",
+		Name:              "machine-name-4",
+		ResourceType:      "type-1",
+		Provider:          providers.AZURE,
+		Region:            "westeurope",
+		ReplicationFactor: 1,
+		Count:             1,
+	},
+	Specs: &resources.ComputeResourceSpecs{
+		VCPUs:    2,
+		MemoryMb: 4096,
+	},
+}
+
 var resourceUnsupportedComputeBasic = resources.ComputeResource{
 	Identification: &resources.ResourceIdentification{
 		Address:           "unsupported.machine-name-3",
 		Name:              "machine-name-3",
 		ResourceType:      "type-1",
-		Provider:          providers.AZURE,
+		Provider:          providers.Provider(42),
 		Region:            "europe-west9",
 		ReplicationFactor: 1,
 		Count:             1,
@@ -97,6 +113,7 @@ func TestEstimateResource(t *testing.T) {
 				Resource:        &resourceGCPComputeBasic,
 				Power:           decimal.NewFromFloat(7.600784000).RoundFloor(10),
 				CarbonEmissions: decimal.NewFromFloat(0.448446256).RoundFloor(10),
+				Water:           decimal.RequireFromString("0.0032762"),
 				AverageCPUUsage: decimal.NewFromFloat(avgCPUUse),
 				TotalCount:      decimal.NewFromInt(1),
 			},
@@ -108,6 +125,7 @@ func TestEstimateResource(t *testing.T) {
 				Resource:        &resourceGCPComputeCPUType,
 				Power:           decimal.NewFromFloat(9.5565660741),
 				CarbonEmissions: decimal.NewFromFloat(0.5638373983),
+				Water:           decimal.RequireFromString("0.0041192095"),
 				AverageCPUUsage: decimal.NewFromFloat(avgCPUUse),
 				TotalCount:      decimal.NewFromInt(1),
 			},
@@ -119,10 +137,23 @@ func TestEstimateResource(t *testing.T) {
 				Resource:        &resourceGCPInstanceGroup,
 				Power:           decimal.NewFromFloat(7.600784000).RoundFloor(10),
 				CarbonEmissions: decimal.NewFromFloat(0.448446256).RoundFloor(10),
+				Water:           decimal.RequireFromString("0.0032762"),
 				AverageCPUUsage: decimal.NewFromFloat(avgCPUUse),
 				TotalCount:      decimal.NewFromInt(3),
 			},
 		},
+		{
+			name: "azure_basic",
+			args: args{resourceAzureComputeBasic},
+			want: &estimation.EstimationResource{
+				Resource:        &resourceAzureComputeBasic,
+				Power:           decimal.NewFromFloat(6.94540224),
+				CarbonEmissions: decimal.NewFromFloat(2.2808700956),
+				Water:           decimal.RequireFromString("0.003023496"),
+				AverageCPUUsage: decimal.NewFromFloat(avgCPUUse),
+				TotalCount:      decimal.NewFromInt(1),
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -151,6 +182,7 @@ func TestEstimateResourceKilo(t *testing.T) {
 				Resource:        &resourceGCPComputeBasic,
 				Power:           decimal.NewFromFloat(7.600784).RoundFloor(10),
 				CarbonEmissions: decimal.NewFromFloat(0.3228813043).RoundFloor(10),
+				Water:           decimal.RequireFromString("2.358864"),
 				AverageCPUUsage: decimal.NewFromFloat(avgCPUUse),
 				TotalCount:      decimal.NewFromInt(1),
 			},
@@ -162,6 +194,7 @@ func TestEstimateResourceKilo(t *testing.T) {
 				Resource:        &resourceGCPComputeCPUType,
 				Power:           decimal.NewFromFloat(9.5565660741).RoundFloor(10),
 				CarbonEmissions: decimal.NewFromFloat(0.4059629268).RoundFloor(10),
+				Water:           decimal.RequireFromString("2.9658308505"),
 				AverageCPUUsage: decimal.NewFromFloat(avgCPUUse),
 				TotalCount:      decimal.NewFromInt(1),
 			},
@@ -187,7 +220,7 @@ func TestEstimateResourceUnsupported(t *testing.T) {
 		{
 			name: "gcp_basic",
 			args: args{resourceUnsupportedComputeBasic},
-			want: &providers.UnsupportedProviderError{Provider: "AZURE"},
+			want: &providers.UnsupportedProviderError{Provider: "Provider(42)"},
 		},
 	}
 	for _, tt := range tests {
@@ -205,6 +238,7 @@ func EqualsEstimationResource(t *testing.T, expected *estimation.EstimationResou
 	assert.Equal(t, expected.Resource, actual.Resource)
 	assert.Equal(t, expected.Power.String(), actual.Power.String())
 	assert.Equal(t, expected.CarbonEmissions.String(), actual.CarbonEmissions.String())
+	assert.Equal(t, expected.Water.String(), actual.Water.String())
 	assert.Equal(t, expected.AverageCPUUsage.String(), actual.AverageCPUUsage.String())
 	assert.Equal(t, expected.TotalCount.String(), actual.TotalCount.String())
 
@@ -214,6 +248,7 @@ func EqualsTotal(t *testing.T, expected *estimation.EstimationTotal, actual *est
 	assert.Equal(t, expected.ResourcesCount, actual.ResourcesCount)
 	assert.Equal(t, expected.Power.String(), actual.Power.String())
 	assert.Equal(t, expected.CarbonEmissions.String(), actual.CarbonEmissions.String())
+	assert.Equal(t, expected.Water.String(), actual.Water.String())
 	assert.Equal(t, expected.ResourcesCount.String(), actual.ResourcesCount.String())
 }
 
@@ -230,6 +265,7 @@ func TestEstimateResources(t *testing.T) {
 			Resource:        &resourceGCPComputeBasic,
 			Power:           decimal.NewFromFloat(7.600784).Round(10),
 			CarbonEmissions: decimal.NewFromFloat(0.448446256).Round(10),
+			Water:           decimal.RequireFromString("0.0032762"),
 			AverageCPUUsage: decimal.NewFromFloat(avgCPUUse),
 			TotalCount:      decimal.NewFromInt(1),
 		},
@@ -237,6 +273,7 @@ func TestEstimateResources(t *testing.T) {
 			Resource:        &resourceGCPComputeCPUType,
 			Power:           decimal.NewFromFloat(9.5565660741),
 			CarbonEmissions: decimal.NewFromFloat(0.5638373983),
+			Water:           decimal.RequireFromString("0.0041192095"),
 			AverageCPUUsage: decimal.NewFromFloat(avgCPUUse),
 			TotalCount:      decimal.NewFromInt(1),
 		},
@@ -244,6 +281,7 @@ func TestEstimateResources(t *testing.T) {
 			Resource:        &resourceGCPInstanceGroup,
 			Power:           decimal.NewFromFloat(7.600784).Round(10),
 			CarbonEmissions: decimal.NewFromFloat(0.448446256).Round(10),
+			Water:           decimal.RequireFromString("0.0032762"),
 			AverageCPUUsage: decimal.NewFromFloat(avgCPUUse),
 			TotalCount:      decimal.NewFromInt(3),
 		},
@@ -274,6 +312,7 @@ func TestEstimateResources(t *testing.T) {
 				Total: estimation.EstimationTotal{
 					Power:           decimal.NewFromFloat(39.9597020741),
 					CarbonEmissions: decimal.NewFromFloat(2.3576224223),
+					Water:           decimal.RequireFromString("0.0172240095"),
 					ResourcesCount:  decimal.NewFromInt(5),
 				},
 			},
@@ -13,7 +13,13 @@ type EstimationReport struct {
 	Info                 EstimationInfo
 	Resources            []EstimationResource
 	UnsupportedResources []resources.Resource
-	Total                EstimationTotal
+	// NeedsUsageResources are serverless resources that cannot be estimated without a declared usage
+	NeedsUsageResources []resources.Resource `json:"NeedsUsageResources,omitempty"`
+	// Allocations are the shares of the estimations of hosts allocated to the workloads running on them
+	Allocations []EstimationAllocation `json:"Allocations,omitempty"`
+	// IdleCapacities are the shares of the estimations of hosts of workloads not allocated to any workload
+	IdleCapacities []EstimationIdleCapacity `json:"IdleCapacities,omitempty"`
+	Total          EstimationTotal
 }
 
 // EstimationResource is the struct that contains the estimation of a resource
@@ -21,14 +27,61 @@ type EstimationResource struct {
 	Resource        resources.Resource
 	Power           decimal.Decimal `json:"PowerPerInstance"`
 	CarbonEmissions decimal.Decimal `json:"CarbonEmissionsPerInstance"`
+	Water           decimal.Decimal `json:"WaterPerInstance"` // in litres
 	AverageCPUUsage decimal.Decimal
 	TotalCount      decimal.Decimal `json:"TotalCount"` // Count * ReplicationFactor
+	PUE             decimal.Decimal `json:"PUE"`
+	CPUPowerModel   string          `json:"CPUPowerModel,omitempty"`
+	Schedule        string          `json:"Schedule,omitempty"`
+	// InstanceMix is the assumed mix of instance types and lifecycles of mixed instances
+	InstanceMix []InstanceShare `json:"InstanceMix,omitempty"`
+	// PowerProfile is set if the power is not estimated from the specs of the resource, but from a profile of lower precision
+	PowerProfile string `json:"PowerProfile,omitempty"`
 }
 
+// InstanceShare is the share of the capacity of mixed instances running an instance type with a lifecycle
+type InstanceShare struct {
+	InstanceType string
+	Lifecycle    string
+	Share        decimal.Decimal
+}
+
+// EstimationAllocation is the share of the estimation of a host resource allocated to a workload running on it.
+// It is already counted in the estimation of the host, so not in the total
+type EstimationAllocation struct {
+	Resource    resources.Resource
+	HostAddress string
+	// Share is the fraction of the host reserved by the workload
+	Share           decimal.Decimal
+	Power           decimal.Decimal
+	CarbonEmissions decimal.Decimal
+	Water           decimal.Decimal // in litres
+}
+
+// EstimationIdleCapacity is the share of the estimation of a host resource not allocated to the workloads running on it.
+// It is already counted in the estimation of the host, so not in the total
+type EstimationIdleCapacity struct {
+	HostAddress     string
+	Share           decimal.Decimal
+	Power           decimal.Decimal
+	CarbonEmissions decimal.Decimal
+	Water           decimal.Decimal // in litres
+}
+
+const (
+	// CPUPowerModelLinear is the CPU power interpolated between min and max watts
+	CPUPowerModelLinear = "linear"
+	// CPUPowerModelCurve is the CPU power interpolated on a measured power curve
+	CPUPowerModelCurve = "curve"
+	// PowerProfileFixed is the power taken from a fixed profile of the resource type
+	PowerProfileFixed = "fixed"
+)
+
 // EstimationTotal is the struct that contains the total estimation
 type EstimationTotal struct {
 	Power           decimal.Decimal
 	CarbonEmissions decimal.Decimal
+	Water           decimal.Decimal
 	ResourcesCount  decimal.Decimal
 }
 
@@ -37,6 +90,7 @@ type EstimationInfo struct {
 	UnitTime                string
 	UnitWattTime            string
 	UnitCarbonEmissionsTime string
+	UnitWaterTime           string
 	DateTime                time.Time
 	InfoByProvider          map[providers.Provider]InfoByProvider
 }
@@ -14,6 +14,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/carboniferio/carbonifer/internal/estimate/estimation"
+	"github.com/carboniferio/carbonifer/internal/resources"
 	"github.com/carboniferio/carbonifer/internal/testutils"
 	_ "github.com/carboniferio/carbonifer/internal/testutils"
 )
@@ -28,12 +29,14 @@ func TestGenerateReportJson_Empty(t *testing.T) {
 			UnitTime:                "h",
 			UnitWattTime:            "w",
 			UnitCarbonEmissionsTime: "gCO2eq/h",
+			UnitWaterTime:           "L/h",
 			DateTime:                now,
 		},
 		Resources: []estimation.EstimationResource{},
 		Total: estimation.EstimationTotal{
 			Power:           decimal.Decimal{},
 			CarbonEmissions: decimal.Decimal{},
+			Water:           decimal.Decimal{},
 			ResourcesCount:  decimal.Zero,
 		},
 	}
@@ -44,6 +47,58 @@ func TestGenerateReportJson_Empty(t *testing.T) {
 	assert.Equal(t, strings.TrimSpace(want), strings.TrimSpace(got))
 }
 
+func TestGenerateReportText_CPUPowerModel(t *testing.T) {
+	estimations := estimation.EstimationReport{
+		Info: estimation.EstimationInfo{
+			UnitTime:                "h",
+			UnitWattTime:            "w",
+			UnitCarbonEmissionsTime: "gCO2eq/h",
+			UnitWaterTime:           "L/h",
+			DateTime:                time.Now(),
+		},
+		Resources: []estimation.EstimationResource{
+			{
+				Resource: resources.ComputeResource{
+					Identification: &resources.ResourceIdentification{
+						Address:           "aws_instance.curve",
+						Count:             1,
+						ReplicationFactor: 1,
+					},
+				},
+				PUE:             decimal.NewFromFloat(1.12),
+				CPUPowerModel:   estimation.CPUPowerModelCurve,
+				CarbonEmissions: decimal.NewFromFloat(12.5),
+				Water:           decimal.NewFromFloat(0.25),
+			},
+			{
+				Resource: resources.ComputeResource{
+					Identification: &resources.ResourceIdentification{
+						Address:           "google_compute_instance.linear",
+						Count:             2,
+						ReplicationFactor: 1,
+						Lifecycle:         "spot",
+					},
+				},
+				PUE:             decimal.NewFromFloat(1.1),
+				CPUPowerModel:   estimation.CPUPowerModelLinear,
+				CarbonEmissions: decimal.NewFromFloat(3.75),
+				Water:           decimal.NewFromFloat(0.05),
+			},
+		},
+		Total: estimation.EstimationTotal{
+			Power:           decimal.Decimal{},
+			CarbonEmissions: decimal.NewFromFloat(20),
+			Water:           decimal.NewFromFloat(0.35),
+			ResourcesCount:  decimal.NewFromInt(3),
+		},
+	}
+
+	want := loadOutput("cpu_power_model.txt")
+	got := GenerateReportText(estimations)
+
+	assert.Equal(t, strings.TrimSpace(want), strings.TrimSpace(got))
+}
+
 func loadOutput(name string) string {
 	jsonFile, err := os.Open(path.Join(testutils.RootDir, "test/outputs", name))
 	if err != nil {
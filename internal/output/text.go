@@ -2,11 +2,14 @@ package output
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/carboniferio/carbonifer/internal/estimate"
 	"github.com/carboniferio/carbonifer/internal/estimate/estimation"
+	"github.com/carboniferio/carbonifer/internal/resources"
 	"github.com/olekukonko/tablewriter"
+	"github.com/shopspring/decimal"
 	log "github.com/sirupsen/logrus"
 )
 
@@ -17,7 +20,7 @@ func GenerateReportText(report estimation.EstimationReport) string {
 	tableString.WriteString("\n  Average estimation of CO2 emissions per instance: \n\n")
 
 	table := tablewriter.NewWriter(tableString)
-	table.SetHeader([]string{"resource", "count", "replicas", "emissions per instance"})
+	table.SetHeader([]string{"resource", "count", "replicas", "lifecycle", "pue", "cpu model", "emissions per instance", "water per instance"})
 
 	// Default sort
 	estimations := report.Resources
@@ -28,7 +31,11 @@ func GenerateReportText(report estimation.EstimationReport) string {
 			resource.Resource.GetAddress(),
 			fmt.Sprintf("%v", resource.Resource.GetIdentification().Count),
 			fmt.Sprintf("%v", resource.Resource.GetIdentification().ReplicationFactor),
+			resource.Resource.GetIdentification().Lifecycle,
+			resource.PUE.StringFixed(2),
+			resource.CPUPowerModel,
 			fmt.Sprintf(" %v %v", resource.CarbonEmissions.StringFixed(4), report.Info.UnitCarbonEmissionsTime),
+			fmt.Sprintf(" %v %v", resource.Water.StringFixed(4), report.Info.UnitWaterTime),
 		})
 	}
 
@@ -37,13 +44,124 @@ func GenerateReportText(report estimation.EstimationReport) string {
 			resource.GetIdentification().Address,
 			"",
 			"",
+			"",
+			"",
+			"",
 			"unsupported",
+			"",
+		})
+	}
+
+	for _, resource := range report.NeedsUsageResources {
+		table.Append([]string{
+			resource.GetIdentification().Address,
+			"",
+			"",
+			"",
+			"",
+			"",
+			"needs usage",
+			"",
 		})
 	}
 
-	table.SetFooter([]string{"Total", report.Total.ResourcesCount.String(), "", fmt.Sprintf(" %v %v", report.Total.CarbonEmissions.StringFixed(4), report.Info.UnitCarbonEmissionsTime)})
+	table.SetFooter([]string{"Total", report.Total.ResourcesCount.String(), "", "", "", "", fmt.Sprintf(" %v %v", report.Total.CarbonEmissions.StringFixed(4), report.Info.UnitCarbonEmissionsTime), fmt.Sprintf(" %v %v", report.Total.Water.StringFixed(4), report.Info.UnitWaterTime)})
 
-	// Format
+	formatTable(table)
+	table.Render()
+
+	// Assumed instance types of mixed instances
+	mixHeaderWritten := false
+	for _, resource := range report.Resources {
+		if len(resource.InstanceMix) == 0 {
+			continue
+		}
+		if !mixHeaderWritten {
+			tableString.WriteString("\n  Assumed mix of instance types: \n\n")
+			mixHeaderWritten = true
+		}
+		shares := []string{}
+		for _, share := range resource.InstanceMix {
+			shares = append(shares, fmt.Sprintf("%v %v %v%%", share.InstanceType, share.Lifecycle, share.Share.Mul(decimal.NewFromInt(100)).StringFixed(1)))
+		}
+		tableString.WriteString(fmt.Sprintf("  %v: %v\n", resource.Resource.GetAddress(), strings.Join(shares, ", ")))
+	}
+
+	// Resources estimated from a fixed profile, less precise than from their specs
+	fixedHeaderWritten := false
+	for _, resource := range report.Resources {
+		if resource.PowerProfile != estimation.PowerProfileFixed {
+			continue
+		}
+		if !fixedHeaderWritten {
+			tableString.WriteString("\n  Estimated from a fixed profile (lower precision): \n\n")
+			fixedHeaderWritten = true
+		}
+		tableString.WriteString(fmt.Sprintf("  %v\n", resource.Resource.GetAddress()))
+	}
+
+	// Workloads allocated a share of their hosts
+	if len(report.Allocations) > 0 {
+		tableString.WriteString("\n  Allocated to workloads (already counted in their hosts): \n\n")
+		allocationTable := tablewriter.NewWriter(tableString)
+		allocationTable.SetHeader([]string{"workload", "namespace", "host", "share", "emissions", "water"})
+		namespaces := []string{}
+		namespacesEmissions := map[string]decimal.Decimal{}
+		namespacesWater := map[string]decimal.Decimal{}
+		for _, allocation := range report.Allocations {
+			namespace := ""
+			if workload, ok := allocation.Resource.(resources.WorkloadResource); ok && workload.Namespace != "" {
+				namespace = workload.Namespace
+				if _, ok := namespacesEmissions[namespace]; !ok {
+					namespaces = append(namespaces, namespace)
+				}
+				namespacesEmissions[namespace] = namespacesEmissions[namespace].Add(allocation.CarbonEmissions)
+				namespacesWater[namespace] = namespacesWater[namespace].Add(allocation.Water)
+			}
+			allocationTable.Append([]string{
+				allocation.Resource.GetAddress(),
+				namespace,
+				allocation.HostAddress,
+				fmt.Sprintf("%v%%", allocation.Share.Mul(decimal.NewFromInt(100)).StringFixed(1)),
+				fmt.Sprintf(" %v %v", allocation.CarbonEmissions.StringFixed(4), report.Info.UnitCarbonEmissionsTime),
+				fmt.Sprintf(" %v %v", allocation.Water.StringFixed(4), report.Info.UnitWaterTime),
+			})
+		}
+		// Share of the hosts not reserved by workloads
+		for _, idleCapacity := range report.IdleCapacities {
+			allocationTable.Append([]string{
+				"(idle capacity)",
+				"",
+				idleCapacity.HostAddress,
+				fmt.Sprintf("%v%%", idleCapacity.Share.Mul(decimal.NewFromInt(100)).StringFixed(1)),
+				fmt.Sprintf(" %v %v", idleCapacity.CarbonEmissions.StringFixed(4), report.Info.UnitCarbonEmissionsTime),
+				fmt.Sprintf(" %v %v", idleCapacity.Water.StringFixed(4), report.Info.UnitWaterTime),
+			})
+		}
+		formatTable(allocationTable)
+		allocationTable.Render()
+
+		// Kubernetes workloads by namespace
+		if len(namespaces) > 0 {
+			tableString.WriteString("\n  Allocated to namespaces: \n\n")
+			sort.Strings(namespaces)
+			namespaceTable := tablewriter.NewWriter(tableString)
+			namespaceTable.SetHeader([]string{"namespace", "emissions", "water"})
+			for _, namespace := range namespaces {
+				namespaceTable.Append([]string{
+					namespace,
+					fmt.Sprintf(" %v %v", namespacesEmissions[namespace].StringFixed(4), report.Info.UnitCarbonEmissionsTime),
+					fmt.Sprintf(" %v %v", namespacesWater[namespace].StringFixed(4), report.Info.UnitWaterTime),
+				})
+			}
+			formatTable(namespaceTable)
+			namespaceTable.Render()
+		}
+	}
+	return tableString.String()
+}
+
+func formatTable(table *tablewriter.Table) {
 	table.SetAutoFormatHeaders(false)
 	table.SetHeaderAlignment(tablewriter.ALIGN_LEFT)
 	table.SetAlignment(tablewriter.ALIGN_LEFT)
@@ -51,7 +169,4 @@ func GenerateReportText(report estimation.EstimationReport) string {
 	table.SetBorder(true)
 	table.SetColumnSeparator(" ")
 	table.SetCenterSeparator(" ")
-
-	table.Render()
-	return tableString.String()
 }
@@ -4,24 +4,17 @@ import (
 	"strings"
 
 	"github.com/carboniferio/carbonifer/internal/utils"
+	log "github.com/sirupsen/logrus"
 )
 
 func getJSON(query string, json interface{}) ([]interface{}, error) {
 
 	if strings.Contains(query, "all_select(") {
-		results, err := utils.GetJSON(query, *TfPlan)
-		if len(results) > 0 && err == nil {
-			return results, nil
-		}
-		return nil, err
+		return getJSONOfPlan(query)
 	}
 
 	if strings.HasPrefix(query, ".configuration") || strings.HasPrefix(query, ".prior_state") || strings.HasPrefix(query, ".planned_values") {
-		results, err := utils.GetJSON(query, *TfPlan)
-		if len(results) > 0 && err == nil {
-			return results, nil
-		}
-		return nil, err
+		return getJSONOfPlan(query)
 	}
 
 	results, err := utils.GetJSON(query, json)
@@ -30,3 +23,16 @@ func getJSON(query string, json interface{}) ([]interface{}, error) {
 	}
 	return nil, err
 }
+
+// getJSONOfPlan runs a query on the Terraform plan, without result if there is no plan (like a resource read alone)
+func getJSONOfPlan(query string) ([]interface{}, error) {
+	if TfPlan == nil {
+		log.Debugf("No Terraform plan to query '%v'", query)
+		return nil, nil
+	}
+	results, err := utils.GetJSON(query, *TfPlan)
+	if len(results) > 0 && err == nil {
+		return results, nil
+	}
+	return nil, err
+}
@@ -337,7 +337,8 @@ func resolvePlaceholder(expression string, context *tfContext) (*string, error)
 		if value[0] == nil {
 			return nil, nil
 		}
-		valueStr := fmt.Sprintf("%v", value[0])
+		// Addresses of resources in modules called with for_each have quotes, like module.x["key"].y
+		valueStr := escapeJQString(fmt.Sprintf("%v", value[0]))
 		return &valueStr, err
 	} else if strings.HasPrefix(expression, "config.") {
 		configProperty := strings.TrimPrefix(expression, "config.")
@@ -356,6 +357,11 @@ func resolvePlaceholder(expression string, context *tfContext) (*string, error)
 	return nil, nil
 }
 
+// escapeJQString escapes a value to be used in a string of a jq query
+func escapeJQString(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value)
+}
+
 func getDefaultValue(key string, context *tfContext) (*valueWithUnit, error) {
 	propertyMappings, ok := (*context.Mapping.Properties)[key]
 	if !ok {
@@ -8,9 +8,11 @@ type Mappings struct {
 }
 
 type GeneralConfig struct {
-	JSONData         *map[string]interface{} `yaml:"json_data,omitempty"`
-	DiskTypes        *DiskTypes              `yaml:"disk_types,omitempty"`
-	IgnoredResources *[]string               `yaml:"ignored_resources,omitempty"`
+	JSONData  *map[string]interface{} `yaml:"json_data,omitempty"`
+	DiskTypes *DiskTypes              `yaml:"disk_types,omitempty"`
+	// StorageReplicationFactors are the number of copies of the data written per disk/storage type
+	StorageReplicationFactors *StorageReplicationFactors `yaml:"storage_replication_factors,omitempty"`
+	IgnoredResources          *[]string                  `yaml:"ignored_resources,omitempty"`
 }
 
 type DiskTypes struct {
@@ -18,6 +20,11 @@ type DiskTypes struct {
 	Types   *map[string]*DiskType `yaml:"types,omitempty"`
 }
 
+type StorageReplicationFactors struct {
+	Default *float64            `yaml:"default,omitempty"`
+	Types   *map[string]float64 `yaml:"types,omitempty"`
+}
+
 type ResourceMapping struct {
 	Paths      []string                         `yaml:"paths"`
 	Type       string                           `yaml:"type"`
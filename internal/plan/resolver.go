@@ -17,6 +17,8 @@ type storage struct {
 	IsSSD            bool
 	OverridePriority int
 	Key              string
+	// ReplicationFactor is the number of copies of the data written by the provider
+	ReplicationFactor decimal.Decimal
 }
 
 func applyReference(valueFound string, propertyMapping *PropertyDefinition, context *tfContext) (interface{}, error) {
@@ -67,27 +69,25 @@ func resolveReference(key string, reference *Reference, context *tfContext) (int
 		return value, nil
 	}
 	if reference.General != "" {
-		for providerDiskType, diskType := range *generalMappings.DiskTypes.Types {
-			if providerDiskType == key {
-				return diskType, nil
-			}
-		}
-		defaultDiskType := generalMappings.DiskTypes.Default
-		if defaultDiskType != nil {
-			return defaultDiskType, nil
+		switch reference.General {
+		case "disk_types":
+			return resolveDiskType(key, generalMappings.DiskTypes), nil
+		case "storage_replication_factors":
+			return resolveStorageReplicationFactor(key, generalMappings.StorageReplicationFactors), nil
+		default:
+			return nil, errors.Errorf("Unknown general reference %v", reference.General)
 		}
-		return SSD, nil
 	}
 	if reference.Paths != nil {
 		templatePlaceholders := map[string]string{
-			"key": key,
+			"key": escapeJQString(key),
 		}
 		paths, err := readPaths(reference.Paths, &templatePlaceholders)
 		if err != nil {
 			return nil, err
 		}
 		for _, path := range paths {
-			referencedItems, err := getJSON(path, *TfPlan)
+			referencedItems, err := getJSONOfPlan(path)
 			if err != nil {
 				errW := errors.Wrapf(err, "Cannot find referenced path in terraform plan: '%v'", path)
 				return nil, errW
@@ -119,6 +119,35 @@ func resolveReference(key string, reference *Reference, context *tfContext) (int
 	return key, nil
 }
 
+func resolveDiskType(key string, diskTypes *DiskTypes) interface{} {
+	for providerDiskType, diskType := range *diskTypes.Types {
+		if providerDiskType == key {
+			return diskType
+		}
+	}
+	defaultDiskType := diskTypes.Default
+	if defaultDiskType != nil {
+		return defaultDiskType
+	}
+	return SSD
+}
+
+// resolveStorageReplicationFactor returns the replication factor of a disk/storage type, 1 if not replicated
+func resolveStorageReplicationFactor(key string, replicationFactors *StorageReplicationFactors) interface{} {
+	if replicationFactors == nil {
+		return 1
+	}
+	if replicationFactors.Types != nil {
+		if replicationFactor, ok := (*replicationFactors.Types)[key]; ok {
+			return replicationFactor
+		}
+	}
+	if replicationFactors.Default != nil {
+		return *replicationFactors.Default
+	}
+	return 1
+}
+
 func applyRegex(valueFound string, propertyMapping *PropertyDefinition, context *tfContext) (interface{}, error) {
 	if propertyMapping == nil || propertyMapping.Regex == nil {
 		return valueFound, nil
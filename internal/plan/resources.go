@@ -128,7 +128,13 @@ func getResourcesOfType(resourceType string, mapping *ResourceMapping) ([]resour
 		}
 		log.Debugf("  Found %d resources of type '%s'", len(resourcesFound), resourceType)
 		for _, resourceI := range resourcesFound {
-			resourcesResultGot, err := GetComputeResource(resourceI, mapping, resourcesResult)
+			var resourcesResultGot []resources.Resource
+			switch mapping.Type {
+			case "workload":
+				resourcesResultGot, err = GetWorkloadResource(resourceI, mapping, resourcesResult)
+			default:
+				resourcesResultGot, err = GetComputeResource(resourceI, mapping, resourcesResult)
+			}
 			if err != nil {
 				errW := errors.Wrapf(err, "Cannot get compute resource for path %v", path)
 				return nil, errW
@@ -144,58 +150,17 @@ func getResourcesOfType(resourceType string, mapping *ResourceMapping) ([]resour
 }
 
 func GetComputeResource(resourceI interface{}, resourceMapping *ResourceMapping, resourcesResult []resources.Resource) ([]resources.Resource, error) {
-	resource := resourceI.(map[string]interface{})
-	resourceAddress := resource["address"].(string)
-	providerName, ok := resource["provider_name"].(string)
-	if !ok {
-		return nil, errors.Errorf("Cannot find provider name for resource %v", resourceAddress)
-	}
-	provider, err := parseProvider(providerName)
-	if err != nil {
-		return nil, nil
-	}
-	contextObject := tfContext{
-		ResourceAddress: resourceAddress,
-		Mapping:         resourceMapping,
-		Resource:        resource,
-		Provider:        provider,
-	}
-	contextObject.RootContext = &contextObject
-	context := &contextObject
-	name, err := getString("name", context)
-	if err != nil {
-		return nil, errors.Wrapf(err, "Cannot get name for resource %v", resourceAddress)
-	}
-	region, err := getString("region", context)
-	if err != nil {
-		return nil, errors.Wrapf(err, "Cannot get region for resource %v", resourceAddress)
-	}
-	if region == nil {
-		region = getDefaultRegion()
-		if region == nil {
-			return nil, errors.Errorf("Cannot find default region for resource %v", resourceAddress)
-		}
-	}
-
-	resourceType, err := getString("type", context)
+	context, identification, err := getResourceIdentification(resourceI, resourceMapping)
 	if err != nil {
-		return nil, errors.Wrapf(err, "Cannot get type for resource %v", resourceAddress)
+		return nil, err
 	}
-
-	index := resource["index"]
-	if index != nil {
-		nameStr := fmt.Sprintf("%s[%d]", *name, int(index.(float64)))
-		name = &nameStr
+	if identification == nil {
+		return nil, nil
 	}
+	resourceAddress := identification.Address
 
 	computeResource := resources.ComputeResource{
-		Identification: &resources.ResourceIdentification{
-			Name:         *name,
-			ResourceType: *resourceType,
-			Provider:     provider,
-			Region:       *region,
-			Address:      resourceAddress,
-		},
+		Identification: identification,
 		Specs: &resources.ComputeResourceSpecs{
 			HddStorage: decimal.Zero,
 			SsdStorage: decimal.Zero,
@@ -217,34 +182,29 @@ func GetComputeResource(resourceI interface{}, resourceMapping *ResourceMapping,
 
 	}
 
+	// Add fractional vCPUs (case of containers)
+	fractionalVCPUs, err := getValue("fractional_vCPUs", context)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Cannot get fractional vCPUs for %v", resourceAddress)
+	}
+	if fractionalVCPUs != nil && fractionalVCPUs.Value != nil {
+		computeResource.Specs.FractionalVCPUs, err = decimal.NewFromString(fmt.Sprintf("%v", fractionalVCPUs.Value))
+		if err != nil {
+			return nil, errors.Wrapf(err, "Cannot parse fractional vCPUs for %v", resourceAddress)
+		}
+	}
+
 	// Add memory
 	memory, err := getValue("memory", context)
 	if err != nil {
 		return nil, errors.Wrapf(err, "Cannot get memory for %v", resourceAddress)
 	}
 	if memory != nil && memory.Value != nil {
-		intValue, err := utils.ParseToInt(memory.Value)
+		memoryMb, err := getMemoryMb(memory)
 		if err != nil {
 			return nil, errors.Wrapf(err, "Cannot parse memory for %v", resourceAddress)
 		}
-		computeResource.Specs.MemoryMb = int32(intValue)
-		unit := strings.ToLower(*memory.Unit)
-		switch unit {
-		case "gb":
-			computeResource.Specs.MemoryMb *= 1024
-		case "tb":
-			computeResource.Specs.MemoryMb *= 1024 * 1024
-		case "pb":
-			computeResource.Specs.MemoryMb *= 1024 * 1024 * 1024
-		case "mb":
-			// nothing to do
-		case "kb":
-			computeResource.Specs.MemoryMb /= 1024
-		case "b":
-			computeResource.Specs.MemoryMb /= 1024 * 1024
-		default:
-			log.Fatalf("Unknown unit for memory: %v", unit)
-		}
+		computeResource.Specs.MemoryMb = memoryMb
 	}
 
 	// Add GPUs
@@ -270,6 +230,22 @@ func GetComputeResource(resourceI interface{}, resourceMapping *ResourceMapping,
 		computeResource.Specs.CPUType = *cpuType
 	}
 
+	// Add lifecycle (spot, preemptible...)
+	lifecycle, err := getString("lifecycle", context)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Cannot get lifecycle for %v", resourceAddress)
+	}
+	if lifecycle != nil {
+		switch *lifecycle {
+		case resources.LifecycleOnDemand:
+			// on-demand is the default, nothing to tag
+		case resources.LifecycleSpot, resources.LifecyclePreemptible:
+			computeResource.Identification.Lifecycle = *lifecycle
+		default:
+			return nil, errors.Errorf("Unknown lifecycle '%v' for %v", *lifecycle, resourceAddress)
+		}
+	}
+
 	// Add replication factor
 	replicationFactor, err := getValue("replication_factor", context)
 	if err != nil {
@@ -286,18 +262,56 @@ func GetComputeResource(resourceI interface{}, resourceMapping *ResourceMapping,
 	}
 
 	// Add count (case of autoscaling group)
-	count, err := getValue("count", context)
+	count, err := getCount(context)
 	if err != nil {
 		return nil, errors.Wrapf(err, "Cannot get count for %v", resourceAddress)
 	}
-	if count != nil && count.Value != nil {
-		intValue, err := utils.ParseToInt(count.Value)
+	computeResource.Identification.Count = count
+
+	// Add mixed instance types (case of autoscaling group with several instance types)
+	mixedInstances, err := getMixedInstances(context)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Cannot get mixed instances for %v", resourceAddress)
+	}
+	computeResource.MixedInstances = mixedInstances
+
+	// Add scaling of serverless resources (functions, serverless containers)
+	if resourceMapping.Type == "serverless" {
+		serverless, err := getServerless(context)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Cannot get serverless scaling for %v", resourceAddress)
+		}
+		computeResource.Serverless = serverless
+	}
+
+	// Add fixed power profile (case of networking appliances)
+	if resourceMapping.Type == "fixed_power" {
+		fixedPower, err := getFixedPower(context)
 		if err != nil {
-			return nil, errors.Wrapf(err, "Cannot parse count for %v", resourceAddress)
+			return nil, errors.Wrapf(err, "Cannot get fixed power for %v", resourceAddress)
 		}
-		computeResource.Identification.Count = int64(intValue)
-	} else {
-		computeResource.Identification.Count = 1
+		computeResource.FixedPower = fixedPower
+	}
+
+	computeResource.ObjectStorage = resourceMapping.Type == "object_storage"
+	computeResource.PodRequests = resourceMapping.Type == "pod_requests"
+
+	// Add cluster (case of node pools)
+	cluster, err := getString("cluster", context)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Cannot get cluster for %v", resourceAddress)
+	}
+	if cluster != nil {
+		computeResource.Cluster = *cluster
+	}
+
+	// Add dedicated host (case of VMs placed on a dedicated host or a sole-tenant node group)
+	dedicatedHost, err := getString("dedicated_host", context)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Cannot get dedicated host for %v", resourceAddress)
+	}
+	if dedicatedHost != nil {
+		computeResource.DedicatedHost = *dedicatedHost
 	}
 
 	// Add storage
@@ -316,22 +330,302 @@ func GetComputeResource(resourceI interface{}, resourceMapping *ResourceMapping,
 	return resourcesResult, nil
 }
 
-func getGPU(gpu map[string]interface{}) ([]string, error) {
-	gpuTypes := []string{}
-	gpuType := gpu["type"].(*valueWithUnit)
-	if gpuType == nil {
-		return nil, errors.Errorf("Cannot find GPU type")
+// getResourceIdentification returns the context and the identification of a resource of the plan,
+// or a nil identification if its provider is not supported
+func getResourceIdentification(resourceI interface{}, resourceMapping *ResourceMapping) (*tfContext, *resources.ResourceIdentification, error) {
+	resource := resourceI.(map[string]interface{})
+	resourceAddress := resource["address"].(string)
+	providerName, ok := resource["provider_name"].(string)
+	if !ok {
+		return nil, nil, errors.Errorf("Cannot find provider name for resource %v", resourceAddress)
 	}
-	count := gpu["count"].(*valueWithUnit)
-	if count != nil && count.Value != nil {
-		intValue, err := utils.ParseToInt(count.Value)
+	contextObject := tfContext{
+		ResourceAddress: resourceAddress,
+		Mapping:         resourceMapping,
+		Resource:        resource,
+	}
+	contextObject.RootContext = &contextObject
+	context := &contextObject
+	provider, err := parseProvider(providerName)
+	if err != nil {
+		// Resources of other providers (like Kubernetes workloads) can run on resources of a supported provider
+		runningProvider, errP := getString("provider", context)
+		if errP != nil {
+			return nil, nil, errors.Wrapf(errP, "Cannot get provider for resource %v", resourceAddress)
+		}
+		if runningProvider == nil {
+			log.Debugf("Cannot find a supported provider for %v", resourceAddress)
+			return nil, nil, nil
+		}
+		provider, err = providers.ParseProvider(*runningProvider)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "Cannot parse provider for resource %v", resourceAddress)
+		}
+	}
+	context.Provider = provider
+	name, err := getString("name", context)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "Cannot get name for resource %v", resourceAddress)
+	}
+	region, err := getString("region", context)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "Cannot get region for resource %v", resourceAddress)
+	}
+	if region == nil {
+		region = getDefaultRegion()
+		if region == nil {
+			return nil, nil, errors.Errorf("Cannot find default region for resource %v", resourceAddress)
+		}
+	}
+
+	resourceType, err := getString("type", context)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "Cannot get type for resource %v", resourceAddress)
+	}
+
+	index := resource["index"]
+	if index != nil {
+		nameStr := fmt.Sprintf("%s[%d]", *name, int(index.(float64)))
+		name = &nameStr
+	}
+
+	return context, &resources.ResourceIdentification{
+		Name:         *name,
+		ResourceType: *resourceType,
+		Provider:     provider,
+		Region:       *region,
+		Address:      resourceAddress,
+	}, nil
+}
+
+// getCount returns the number of instances of a resource, 1 by default
+func getCount(context *tfContext) (int64, error) {
+	count, err := getValue("count", context)
+	if err != nil {
+		return 0, err
+	}
+	if count == nil || count.Value == nil {
+		return 1, nil
+	}
+	intValue, err := utils.ParseToInt(count.Value)
+	if err != nil {
+		return 0, errors.Wrapf(err, "Cannot parse count")
+	}
+	return int64(intValue), nil
+}
+
+// getMemoryMb returns the memory in MB of a value in the unit of the mapping
+func getMemoryMb(memory *valueWithUnit) (int32, error) {
+	intValue, err := utils.ParseToInt(memory.Value)
+	if err != nil {
+		return 0, err
+	}
+	memoryMb := int32(intValue)
+	unit := strings.ToLower(*memory.Unit)
+	switch unit {
+	case "gb":
+		memoryMb *= 1024
+	case "tb":
+		memoryMb *= 1024 * 1024
+	case "pb":
+		memoryMb *= 1024 * 1024 * 1024
+	case "mb":
+		// nothing to do
+	case "kb":
+		memoryMb /= 1024
+	case "b":
+		memoryMb /= 1024 * 1024
+	default:
+		log.Fatalf("Unknown unit for memory: %v", unit)
+	}
+	return memoryMb, nil
+}
+
+// getMixedInstances returns the instance types and the on-demand/spot split of mixed instances,
+// or nil if the resource has a single instance type
+func getMixedInstances(context *tfContext) (*resources.MixedInstances, error) {
+	instanceTypesI, err := getSlice("mixed_instance_types", context)
+	if err != nil {
+		return nil, err
+	}
+	if len(instanceTypesI) == 0 {
+		return nil, nil
+	}
+
+	mixedInstances := resources.MixedInstances{
+		OnDemandPercentageAboveBaseCapacity: decimal.NewFromInt(100),
+	}
+	for i, instanceTypeI := range instanceTypesI {
+		instanceType, err := getMixedInstanceType(instanceTypeI.(map[string]interface{}))
+		if err != nil {
+			return nil, errors.Wrapf(err, "Cannot get mixed instance type[%v]", i)
+		}
+		mixedInstances.InstanceTypes = append(mixedInstances.InstanceTypes, *instanceType)
+	}
+
+	onDemandBaseCapacity, err := getValue("on_demand_base_capacity", context)
+	if err != nil {
+		return nil, err
+	}
+	if onDemandBaseCapacity != nil && onDemandBaseCapacity.Value != nil {
+		intValue, err := utils.ParseToInt(onDemandBaseCapacity.Value)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Cannot parse on-demand base capacity")
+		}
+		mixedInstances.OnDemandBaseCapacity = int64(intValue)
+	}
+	onDemandPercentage, err := getValue("on_demand_percentage_above_base_capacity", context)
+	if err != nil {
+		return nil, err
+	}
+	if onDemandPercentage != nil && onDemandPercentage.Value != nil {
+		mixedInstances.OnDemandPercentageAboveBaseCapacity, err = decimal.NewFromString(fmt.Sprintf("%v", onDemandPercentage.Value))
+		if err != nil {
+			return nil, errors.Wrapf(err, "Cannot parse on-demand percentage above base capacity")
+		}
+	}
+	return &mixedInstances, nil
+}
+
+// getServerless returns the min instances and the concurrency of a serverless resource, by default 0 and 1
+func getServerless(context *tfContext) (*resources.Serverless, error) {
+	serverless := resources.Serverless{
+		MinInstances: 0,
+		Concurrency:  1,
+	}
+	minInstances, err := getValue("min_instances", context)
+	if err != nil {
+		return nil, err
+	}
+	if minInstances != nil && minInstances.Value != nil {
+		intValue, err := utils.ParseToInt(minInstances.Value)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Cannot parse min instances")
+		}
+		serverless.MinInstances = int64(intValue)
+	}
+	concurrency, err := getValue("concurrency", context)
+	if err != nil {
+		return nil, err
+	}
+	if concurrency != nil && concurrency.Value != nil {
+		intValue, err := utils.ParseToInt(concurrency.Value)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Cannot parse concurrency")
+		}
+		if intValue > 0 {
+			serverless.Concurrency = int64(intValue)
+		}
+	}
+	return &serverless, nil
+}
+
+// getFixedPower returns the fixed power profile of a resource, without capacity units by default
+func getFixedPower(context *tfContext) (*resources.FixedPower, error) {
+	fixedPower := resources.FixedPower{
+		Watts:         decimal.Zero,
+		WattsPerUnit:  decimal.Zero,
+		CapacityUnits: decimal.Zero,
+	}
+	properties := []struct {
+		name  string
+		value *decimal.Decimal
+	}{
+		{"watts", &fixedPower.Watts},
+		{"watts_per_unit", &fixedPower.WattsPerUnit},
+		{"capacity_units", &fixedPower.CapacityUnits},
+	}
+	for _, property := range properties {
+		value, err := getValue(property.name, context)
 		if err != nil {
 			return nil, err
 		}
-		for i := 0; i < intValue; i++ {
-			gpuTypeValue := gpuType.Value.(string)
-			gpuTypes = append(gpuTypes, gpuTypeValue)
+		if value == nil || value.Value == nil {
+			continue
+		}
+		*property.value, err = decimal.NewFromString(fmt.Sprintf("%v", value.Value))
+		if err != nil {
+			return nil, errors.Wrapf(err, "Cannot parse %v", property.name)
+		}
+	}
+	if fixedPower.Watts.IsZero() && fixedPower.WattsPerUnit.IsZero() {
+		return nil, errors.New("No fixed power profile found")
+	}
+	return &fixedPower, nil
+}
+
+func getMixedInstanceType(instanceTypeMap map[string]interface{}) (*resources.MixedInstanceType, error) {
+	name, ok := instanceTypeMap["instance_type"].(*valueWithUnit)
+	if !ok || name.Value == nil {
+		return nil, errors.Errorf("Cannot find instance type in '%v'", instanceTypeMap)
+	}
+	instanceType := resources.MixedInstanceType{
+		InstanceType:     fmt.Sprintf("%v", name.Value),
+		WeightedCapacity: decimal.NewFromInt(1),
+		Specs: &resources.ComputeResourceSpecs{
+			HddStorage: decimal.Zero,
+			SsdStorage: decimal.Zero,
+		},
+	}
+	if weightedCapacity, ok := instanceTypeMap["weighted_capacity"].(*valueWithUnit); ok && weightedCapacity.Value != nil {
+		value, err := decimal.NewFromString(fmt.Sprintf("%v", weightedCapacity.Value))
+		if err != nil {
+			return nil, errors.Wrapf(err, "Cannot parse weighted capacity of %v", instanceType.InstanceType)
+		}
+		if value.IsPositive() {
+			instanceType.WeightedCapacity = value
+		}
+	}
+	if vcpus, ok := instanceTypeMap["vCPUs"].(*valueWithUnit); ok && vcpus.Value != nil {
+		intValue, err := utils.ParseToInt(vcpus.Value)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Cannot parse vCPUs of %v", instanceType.InstanceType)
+		}
+		instanceType.Specs.VCPUs = int32(intValue)
+	}
+	if memory, ok := instanceTypeMap["memory"].(*valueWithUnit); ok && memory.Value != nil {
+		memoryMb, err := getMemoryMb(memory)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Cannot parse memory of %v", instanceType.InstanceType)
 		}
+		instanceType.Specs.MemoryMb = memoryMb
+	}
+	if cpuType, ok := instanceTypeMap["cpu_platform"].(*valueWithUnit); ok && cpuType.Value != nil {
+		instanceType.Specs.CPUType = fmt.Sprintf("%v", cpuType.Value)
+	}
+	gpuTypes, err := getGPU(map[string]interface{}{
+		"count": instanceTypeMap["gpu_count"],
+		"type":  instanceTypeMap["gpu_type"],
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "Cannot get GPU types of %v", instanceType.InstanceType)
+	}
+	if len(gpuTypes) > 0 {
+		instanceType.Specs.GpuTypes = gpuTypes
+	}
+	return &instanceType, nil
+}
+
+func getGPU(gpu map[string]interface{}) ([]string, error) {
+	gpuTypes := []string{}
+	count, _ := gpu["count"].(*valueWithUnit)
+	if count == nil || count.Value == nil {
+		return gpuTypes, nil
+	}
+	intValue, err := utils.ParseToInt(count.Value)
+	if err != nil {
+		return nil, err
+	}
+	if intValue == 0 {
+		return gpuTypes, nil
+	}
+	gpuType, _ := gpu["type"].(*valueWithUnit)
+	if gpuType == nil {
+		return nil, errors.Errorf("Cannot find GPU type")
+	}
+	gpuTypeValue := gpuType.Value.(string)
+	for i := 0; i < intValue; i++ {
+		gpuTypes = append(gpuTypes, gpuTypeValue)
 	}
 	return gpuTypes, nil
 }
@@ -346,6 +640,9 @@ func processStorages(storagesI []interface{}, computeResource *resources.Compute
 		if err != nil {
 			return errors.Wrapf(err, "Cannot get storage[%v] for %v", i, context.ResourceAddress)
 		}
+		if storageItem == nil {
+			continue
+		}
 		if storagesByKey[storageItem.Key] == nil {
 			storagesByKey[storageItem.Key] = []*storage{storageItem}
 		} else {
@@ -375,18 +672,52 @@ func processStorages(storagesI []interface{}, computeResource *resources.Compute
 		}
 	}
 
+	ssdStorages := []*storage{}
+	hddStorages := []*storage{}
 	for _, storageItem := range storagesOverriden {
 		size := storageItem.SizeGb
 		if storageItem.IsSSD {
 			computeResource.Specs.SsdStorage = computeResource.Specs.SsdStorage.Add(size)
+			ssdStorages = append(ssdStorages, storageItem)
 		} else {
 			computeResource.Specs.HddStorage = computeResource.Specs.HddStorage.Add(size)
+			hddStorages = append(hddStorages, storageItem)
 		}
 	}
+	computeResource.Specs.SsdStorageReplicationFactor = getStorageReplicationFactor(ssdStorages)
+	computeResource.Specs.HddStorageReplicationFactor = getStorageReplicationFactor(hddStorages)
 
 	return nil
 }
 
+// getStorageReplicationFactor returns the replication factor of the storages, averaged over their size if it differs,
+// or zero if they are not replicated
+func getStorageReplicationFactor(storages []*storage) decimal.Decimal {
+	replicationFactor := decimal.Zero
+	totalSize := decimal.Zero
+	replicatedSize := decimal.Zero
+	for i, storageItem := range storages {
+		if i == 0 {
+			replicationFactor = storageItem.ReplicationFactor
+		} else if !replicationFactor.Equal(storageItem.ReplicationFactor) {
+			replicationFactor = decimal.Zero
+		}
+		totalSize = totalSize.Add(storageItem.SizeGb)
+		replicatedSize = replicatedSize.Add(storageItem.SizeGb.Mul(storageItem.ReplicationFactor))
+	}
+	// Same replication factor for all storages, even of unknown size (like buckets sized by their usage)
+	if !replicationFactor.IsZero() {
+		if replicationFactor.Equal(decimal.NewFromInt(1)) {
+			return decimal.Decimal{}
+		}
+		return replicationFactor
+	}
+	if totalSize.IsZero() || replicatedSize.Equal(totalSize) {
+		return decimal.Decimal{}
+	}
+	return replicatedSize.Div(totalSize)
+}
+
 func sortStorages(storages []*storage) []*storage {
 	if len(storages) == 0 {
 		return storages
@@ -416,7 +747,7 @@ func getStorage(storageMap map[string]interface{}) (*storage, error) {
 	if err != nil {
 		log.Fatal(err)
 	}
-	storageType := storageMap["type"].(*valueWithUnit)
+	storageType, _ := storageMap["type"].(*valueWithUnit)
 	// TODO get storage size unit correctly
 	unit := storageSize.Unit
 	if unit != nil {
@@ -465,11 +796,22 @@ func getStorage(storageMap map[string]interface{}) (*storage, error) {
 		key = keyString
 	}
 
+	replicationFactor := decimal.NewFromInt(1)
+	replicationFactorI := storageMap["replication_factor"]
+	if replicationFactorI != nil {
+		replicationFactorValue := replicationFactorI.(*valueWithUnit).Value
+		replicationFactor, err = decimal.NewFromString(fmt.Sprintf("%v", replicationFactorValue))
+		if err != nil {
+			return nil, errors.Wrapf(err, "Cannot parse storage replication factor '%v'", replicationFactorValue)
+		}
+	}
+
 	storage := storage{
-		SizeGb:           storageSizeGb,
-		IsSSD:            isSSD,
-		OverridePriority: overridePriority,
-		Key:              key,
+		SizeGb:            storageSizeGb,
+		IsSSD:             isSSD,
+		OverridePriority:  overridePriority,
+		Key:               key,
+		ReplicationFactor: replicationFactor,
 	}
 	return &storage, nil
 }
@@ -481,5 +823,8 @@ func parseProvider(tfProviderName string) (providers.Provider, error) {
 	if strings.HasSuffix(tfProviderName, "aws") {
 		return providers.ParseProvider("aws")
 	}
+	if strings.HasSuffix(tfProviderName, "azurerm") {
+		return providers.ParseProvider("azure")
+	}
 	return providers.ParseProvider(tfProviderName)
 }
@@ -0,0 +1,392 @@
+package plan_test
+
+import (
+	"testing"
+
+	"github.com/carboniferio/carbonifer/internal/plan"
+	"github.com/carboniferio/carbonifer/internal/providers"
+	"github.com/carboniferio/carbonifer/internal/resources"
+	"github.com/carboniferio/carbonifer/internal/testutils"
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetResource_AnalyticsClusters(t *testing.T) {
+	mapping, err := plan.GetMapping()
+	assert.NoError(t, err)
+	plan.TfPlan = &map[string]interface{}{
+		"configuration": map[string]interface{}{
+			"provider_config": map[string]interface{}{
+				"aws": map[string]interface{}{
+					"expressions": map[string]interface{}{
+						"region": map[string]interface{}{"constant_value": "eu-west-3"},
+					},
+				},
+			},
+		},
+	}
+	defer func() { plan.TfPlan = &map[string]interface{}{} }()
+
+	dataprocCluster := tfjson.StateResource{
+		Address:      "google_dataproc_cluster.spark",
+		Type:         "google_dataproc_cluster",
+		Name:         "spark",
+		ProviderName: "registry.terraform.io/hashicorp/google",
+		AttributeValues: map[string]interface{}{
+			"region": "europe-west1",
+			"cluster_config": []interface{}{
+				map[string]interface{}{
+					"master_config": []interface{}{
+						map[string]interface{}{
+							"num_instances": 1,
+							"machine_type":  "n1-standard-2",
+							"disk_config": []interface{}{
+								map[string]interface{}{"boot_disk_type": "pd-ssd", "boot_disk_size_gb": 100},
+							},
+						},
+					},
+					"worker_config": []interface{}{
+						map[string]interface{}{
+							"num_instances": 4,
+							"machine_type":  "n1-standard-2",
+							"disk_config": []interface{}{
+								map[string]interface{}{"num_local_ssds": 1},
+							},
+						},
+					},
+					"preemptible_worker_config": []interface{}{
+						map[string]interface{}{
+							"num_instances":  10,
+							"preemptibility": "SPOT",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		mapping  string
+		resource tfjson.StateResource
+		want     resources.ComputeResource
+	}{
+		{
+			name:    "redshift dc2",
+			mapping: "aws_redshift_cluster",
+			resource: tfjson.StateResource{
+				Address:      "aws_redshift_cluster.warehouse",
+				Type:         "aws_redshift_cluster",
+				Name:         "warehouse",
+				ProviderName: "registry.terraform.io/hashicorp/aws",
+				AttributeValues: map[string]interface{}{
+					"node_type":       "dc2.large",
+					"cluster_type":    "multi-node",
+					"number_of_nodes": 4,
+				},
+			},
+			want: resources.ComputeResource{
+				Identification: &resources.ResourceIdentification{
+					Address:           "aws_redshift_cluster.warehouse",
+					Name:              "warehouse",
+					ResourceType:      "aws_redshift_cluster",
+					Provider:          providers.AWS,
+					Region:            "eu-west-3",
+					Count:             4,
+					ReplicationFactor: 1,
+				},
+				Specs: &resources.ComputeResourceSpecs{
+					VCPUs:      2,
+					MemoryMb:   15360,
+					CPUType:    "Broadwell",
+					HddStorage: decimal.Zero,
+					SsdStorage: decimal.NewFromInt(160),
+				},
+			},
+		},
+		{
+			name:    "redshift node type not in catalog",
+			mapping: "aws_redshift_cluster",
+			resource: tfjson.StateResource{
+				Address:      "aws_redshift_cluster.unknown",
+				Type:         "aws_redshift_cluster",
+				Name:         "unknown",
+				ProviderName: "registry.terraform.io/hashicorp/aws",
+				AttributeValues: map[string]interface{}{
+					"node_type":         "ds2.16xlarge",
+					"number_of_nodes":   2,
+					"availability_zone": "eu-west-1b",
+				},
+			},
+			want: resources.ComputeResource{
+				Identification: &resources.ResourceIdentification{
+					Address:           "aws_redshift_cluster.unknown",
+					Name:              "unknown",
+					ResourceType:      "aws_redshift_cluster",
+					Provider:          providers.AWS,
+					Region:            "eu-west-1",
+					Count:             2,
+					ReplicationFactor: 1,
+				},
+				Specs: &resources.ComputeResourceSpecs{
+					HddStorage: decimal.NewFromInt(0),
+					SsdStorage: decimal.Zero,
+				},
+			},
+		},
+		{
+			name:    "redshift ra3 single node",
+			mapping: "aws_redshift_cluster",
+			resource: tfjson.StateResource{
+				Address:      "aws_redshift_cluster.small",
+				Type:         "aws_redshift_cluster",
+				Name:         "small",
+				ProviderName: "registry.terraform.io/hashicorp/aws",
+				AttributeValues: map[string]interface{}{
+					"node_type":         "ra3.xlplus",
+					"cluster_type":      "single-node",
+					"number_of_nodes":   1,
+					"availability_zone": "eu-west-1b",
+				},
+			},
+			want: resources.ComputeResource{
+				Identification: &resources.ResourceIdentification{
+					Address:           "aws_redshift_cluster.small",
+					Name:              "small",
+					ResourceType:      "aws_redshift_cluster",
+					Provider:          providers.AWS,
+					Region:            "eu-west-1",
+					Count:             1,
+					ReplicationFactor: 1,
+				},
+				Specs: &resources.ComputeResourceSpecs{
+					VCPUs:      4,
+					MemoryMb:   32768,
+					CPUType:    "Cascade Lake",
+					HddStorage: decimal.Zero,
+					SsdStorage: decimal.Zero,
+				},
+			},
+		},
+		{
+			name:    "emr primary instance group",
+			mapping: "aws_emr_cluster",
+			resource: tfjson.StateResource{
+				Address:      "aws_emr_cluster.spark",
+				Type:         "aws_emr_cluster",
+				Name:         "spark",
+				ProviderName: "registry.terraform.io/hashicorp/aws",
+				AttributeValues: map[string]interface{}{
+					"ebs_root_volume_size": 20,
+					"master_instance_group": []interface{}{
+						map[string]interface{}{
+							"instance_type":  "m5.xlarge",
+							"instance_count": 1,
+							"ebs_config": []interface{}{
+								map[string]interface{}{"size": 32, "type": "gp3", "volumes_per_instance": 2},
+							},
+						},
+					},
+				},
+			},
+			want: resources.ComputeResource{
+				Identification: &resources.ResourceIdentification{
+					Address:           "aws_emr_cluster.spark",
+					Name:              "spark",
+					ResourceType:      "aws_emr_cluster",
+					Provider:          providers.AWS,
+					Region:            "eu-west-3",
+					Count:             1,
+					ReplicationFactor: 1,
+				},
+				Specs: &resources.ComputeResourceSpecs{
+					VCPUs:                       4,
+					MemoryMb:                    16384,
+					CPUType:                     "Skylake",
+					HddStorage:                  decimal.Zero,
+					SsdStorage:                  decimal.NewFromInt(84),
+					SsdStorageReplicationFactor: decimal.NewFromInt(2),
+				},
+			},
+		},
+		{
+			name:    "emr spot task instance group",
+			mapping: "aws_emr_instance_group",
+			resource: tfjson.StateResource{
+				Address:      "aws_emr_instance_group.task",
+				Type:         "aws_emr_instance_group",
+				Name:         "task",
+				ProviderName: "registry.terraform.io/hashicorp/aws",
+				AttributeValues: map[string]interface{}{
+					"instance_type":  "c5.xlarge",
+					"instance_count": 5,
+					"bid_price":      "0.10",
+				},
+			},
+			want: resources.ComputeResource{
+				Identification: &resources.ResourceIdentification{
+					Address:           "aws_emr_instance_group.task",
+					Name:              "task",
+					ResourceType:      "aws_emr_instance_group",
+					Provider:          providers.AWS,
+					Region:            "eu-west-3",
+					Count:             5,
+					ReplicationFactor: 1,
+					Lifecycle:         resources.LifecycleSpot,
+				},
+				Specs: &resources.ComputeResourceSpecs{
+					VCPUs:      4,
+					MemoryMb:   8192,
+					CPUType:    "Skylake",
+					HddStorage: decimal.Zero,
+					SsdStorage: decimal.Zero,
+				},
+			},
+		},
+		{
+			name:     "dataproc master",
+			mapping:  "google_dataproc_cluster",
+			resource: dataprocCluster,
+			want: resources.ComputeResource{
+				Identification: &resources.ResourceIdentification{
+					Address:           "google_dataproc_cluster.spark",
+					Name:              "spark",
+					ResourceType:      "google_dataproc_cluster",
+					Provider:          providers.GCP,
+					Region:            "europe-west1",
+					Count:             1,
+					ReplicationFactor: 1,
+				},
+				Specs: &resources.ComputeResourceSpecs{
+					VCPUs:      2,
+					MemoryMb:   7680,
+					HddStorage: decimal.Zero,
+					SsdStorage: decimal.NewFromInt(100),
+				},
+			},
+		},
+		{
+			name:     "dataproc primary workers",
+			mapping:  "google_dataproc_cluster_worker",
+			resource: dataprocCluster,
+			want: resources.ComputeResource{
+				Identification: &resources.ResourceIdentification{
+					Address:           "google_dataproc_cluster.spark",
+					Name:              "spark",
+					ResourceType:      "google_dataproc_cluster",
+					Provider:          providers.GCP,
+					Region:            "europe-west1",
+					Count:             4,
+					ReplicationFactor: 1,
+				},
+				Specs: &resources.ComputeResourceSpecs{
+					VCPUs:      2,
+					MemoryMb:   7680,
+					HddStorage: decimal.NewFromInt(500),
+					SsdStorage: decimal.NewFromInt(375),
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resource, _ := testutils.TfResourceToJSON(&tt.resource)
+			resourceMapping := (*mapping.ComputeResource)[tt.mapping]
+			got, err := plan.GetComputeResource(*resource, &resourceMapping, nil)
+			assert.NoError(t, err)
+			assert.Len(t, got, 1)
+			assert.Equal(t, tt.want, got[0])
+		})
+	}
+}
+
+func TestGetResources_AnalyticsNodeGroups(t *testing.T) {
+	tfPlan := map[string]interface{}{
+		"planned_values": map[string]interface{}{
+			"root_module": map[string]interface{}{
+				"resources": []interface{}{
+					map[string]interface{}{
+						"address":       "aws_emr_cluster.spark",
+						"type":          "aws_emr_cluster",
+						"name":          "spark",
+						"provider_name": "registry.terraform.io/hashicorp/aws",
+						"values": map[string]interface{}{
+							"master_instance_group": []interface{}{
+								map[string]interface{}{"instance_type": "m5.xlarge"},
+							},
+							"core_instance_fleet": []interface{}{
+								map[string]interface{}{
+									"target_on_demand_capacity": 2,
+									"target_spot_capacity":      6,
+									"instance_type_configs": []interface{}{
+										map[string]interface{}{"instance_type": "m5.xlarge", "weighted_capacity": 1},
+										map[string]interface{}{"instance_type": "m5.2xlarge", "weighted_capacity": 2},
+									},
+								},
+							},
+						},
+					},
+					map[string]interface{}{
+						"address":       "google_dataproc_cluster.spark",
+						"type":          "google_dataproc_cluster",
+						"name":          "spark",
+						"provider_name": "registry.terraform.io/hashicorp/google",
+						"values": map[string]interface{}{
+							"region": "europe-west1",
+							"cluster_config": []interface{}{
+								map[string]interface{}{
+									"master_config": []interface{}{
+										map[string]interface{}{"machine_type": "n1-standard-2"},
+									},
+									"worker_config": []interface{}{
+										map[string]interface{}{"machine_type": "n1-standard-2"},
+									},
+									"preemptible_worker_config": []interface{}{
+										map[string]interface{}{"num_instances": 10, "preemptibility": "SPOT"},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		"configuration": map[string]interface{}{
+			"provider_config": map[string]interface{}{
+				"aws": map[string]interface{}{
+					"expressions": map[string]interface{}{
+						"region": map[string]interface{}{"constant_value": "eu-west-3"},
+					},
+				},
+			},
+		},
+	}
+	defer func() { plan.TfPlan = &map[string]interface{}{} }()
+
+	got, err := plan.GetResources(&tfPlan)
+	assert.NoError(t, err)
+
+	counts := map[string]int64{}
+	lifecycles := map[string]string{}
+	for address, resource := range got {
+		if computeResource, ok := resource.(resources.ComputeResource); ok {
+			counts[address] = computeResource.Identification.Count
+			lifecycles[address] = computeResource.Identification.Lifecycle
+		}
+	}
+	assert.Equal(t, map[string]int64{
+		"aws_emr_cluster.spark":                          1,
+		"aws_emr_cluster.spark.core":                     8,
+		"google_dataproc_cluster.spark":                  1,
+		"google_dataproc_cluster.spark.worker":           2,
+		"google_dataproc_cluster.spark.secondary_worker": 10,
+	}, counts)
+	assert.Equal(t, resources.LifecycleSpot, lifecycles["google_dataproc_cluster.spark.secondary_worker"])
+	assert.Equal(t, "", lifecycles["google_dataproc_cluster.spark.worker"])
+
+	core := got["aws_emr_cluster.spark.core"].(resources.ComputeResource)
+	assert.Len(t, core.MixedInstances.InstanceTypes, 2)
+	assert.Equal(t, int64(2), core.MixedInstances.OnDemandBaseCapacity)
+	assert.True(t, core.MixedInstances.OnDemandPercentageAboveBaseCapacity.IsZero())
+}
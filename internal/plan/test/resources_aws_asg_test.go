@@ -38,9 +38,11 @@ func TestGetResource_AWSASG(t *testing.T) {
 			Specs: &resources.ComputeResourceSpecs{
 				VCPUs:    int32(4),
 				MemoryMb: int32(16384),
+				CPUType:  "Skylake",
 
-				HddStorage: decimal.Zero,
-				SsdStorage: decimal.NewFromInt(180),
+				HddStorage:                  decimal.Zero,
+				SsdStorage:                  decimal.NewFromInt(180),
+				SsdStorageReplicationFactor: decimal.NewFromInt(210).Div(decimal.NewFromInt(180)),
 			},
 		},
 		"aws_autoscaling_group.asg_launch_template": resources.ComputeResource{
@@ -56,9 +58,11 @@ func TestGetResource_AWSASG(t *testing.T) {
 			Specs: &resources.ComputeResourceSpecs{
 				VCPUs:    int32(4),
 				MemoryMb: int32(16384),
+				CPUType:  "Skylake",
 
-				HddStorage: decimal.NewFromInt(300),
-				SsdStorage: decimal.NewFromInt(150),
+				HddStorage:                  decimal.NewFromInt(300),
+				SsdStorage:                  decimal.NewFromInt(150),
+				HddStorageReplicationFactor: decimal.NewFromInt(2),
 			},
 		},
 	}
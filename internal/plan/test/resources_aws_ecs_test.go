@@ -0,0 +1,331 @@
+package plan_test
+
+import (
+	"testing"
+
+	"github.com/carboniferio/carbonifer/internal/plan"
+	"github.com/carboniferio/carbonifer/internal/providers"
+	"github.com/carboniferio/carbonifer/internal/resources"
+	"github.com/carboniferio/carbonifer/internal/testutils"
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetResource_ECSFargate(t *testing.T) {
+	mapping, err := plan.GetMapping()
+	assert.NoError(t, err)
+	plan.TfPlan = &map[string]interface{}{
+		"planned_values": map[string]interface{}{
+			"root_module": map[string]interface{}{
+				"resources": []interface{}{
+					map[string]interface{}{
+						"address": "aws_ecs_task_definition.app",
+						"type":    "aws_ecs_task_definition",
+						"values": map[string]interface{}{
+							"cpu":    "512",
+							"memory": "2048",
+							"runtime_platform": []interface{}{
+								map[string]interface{}{"cpu_architecture": "ARM64"},
+							},
+						},
+					},
+					map[string]interface{}{
+						"address": "aws_appautoscaling_target.app",
+						"type":    "aws_appautoscaling_target",
+						"values": map[string]interface{}{
+							"min_capacity": 2,
+							"max_capacity": 10,
+						},
+					},
+				},
+			},
+		},
+		"configuration": map[string]interface{}{
+			"provider_config": map[string]interface{}{
+				"aws": map[string]interface{}{
+					"expressions": map[string]interface{}{
+						"region": map[string]interface{}{"constant_value": "eu-west-3"},
+					},
+				},
+			},
+			"root_module": map[string]interface{}{
+				"resources": []interface{}{
+					map[string]interface{}{
+						"address": "aws_ecs_service.scaled",
+						"expressions": map[string]interface{}{
+							"task_definition": map[string]interface{}{
+								"references": []interface{}{"aws_ecs_task_definition.app.arn", "aws_ecs_task_definition.app"},
+							},
+						},
+					},
+					map[string]interface{}{
+						"address": "aws_appautoscaling_target.app",
+						"type":    "aws_appautoscaling_target",
+						"expressions": map[string]interface{}{
+							"resource_id": map[string]interface{}{
+								"references": []interface{}{"aws_ecs_service.scaled.name", "aws_ecs_service.scaled"},
+							},
+						},
+					},
+					map[string]interface{}{
+						"address": "aws_ecs_service.spot",
+						"expressions": map[string]interface{}{
+							"task_definition": map[string]interface{}{
+								"references": []interface{}{"aws_ecs_task_definition.app.arn", "aws_ecs_task_definition.app"},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	defer func() { plan.TfPlan = &map[string]interface{}{} }()
+
+	tests := []struct {
+		name   string
+		values map[string]interface{}
+		want   resources.ComputeResource
+	}{
+		{
+			name: "scaled",
+			values: map[string]interface{}{
+				"launch_type":   "FARGATE",
+				"desired_count": 2,
+			},
+			want: resources.ComputeResource{
+				Identification: &resources.ResourceIdentification{
+					Address:           "aws_ecs_service.scaled",
+					Name:              "scaled",
+					ResourceType:      "aws_ecs_service",
+					Provider:          providers.AWS,
+					Region:            "eu-west-3",
+					Count:             6,
+					ReplicationFactor: 1,
+				},
+				Specs: &resources.ComputeResourceSpecs{
+					FractionalVCPUs: decimal.NewFromFloat(0.5),
+					MemoryMb:        2048,
+					CPUType:         "Graviton2",
+					HddStorage:      decimal.Zero,
+					SsdStorage:      decimal.NewFromInt(20),
+				},
+			},
+		},
+		{
+			name: "spot",
+			values: map[string]interface{}{
+				"desired_count": 3,
+				"capacity_provider_strategy": []interface{}{
+					map[string]interface{}{"capacity_provider": "FARGATE_SPOT", "weight": 1},
+				},
+			},
+			want: resources.ComputeResource{
+				Identification: &resources.ResourceIdentification{
+					Address:           "aws_ecs_service.spot",
+					Name:              "spot",
+					ResourceType:      "aws_ecs_service",
+					Provider:          providers.AWS,
+					Region:            "eu-west-3",
+					Count:             3,
+					ReplicationFactor: 1,
+					Lifecycle:         resources.LifecycleSpot,
+				},
+				Specs: &resources.ComputeResourceSpecs{
+					FractionalVCPUs: decimal.NewFromFloat(0.5),
+					MemoryMb:        2048,
+					CPUType:         "Graviton2",
+					HddStorage:      decimal.Zero,
+					SsdStorage:      decimal.NewFromInt(20),
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tfResource := tfjson.StateResource{
+				Address:         "aws_ecs_service." + tt.name,
+				Type:            "aws_ecs_service",
+				Name:            tt.name,
+				ProviderName:    "registry.terraform.io/hashicorp/aws",
+				AttributeValues: tt.values,
+			}
+			resource, _ := testutils.TfResourceToJSON(&tfResource)
+			serviceMapping := (*mapping.ComputeResource)["aws_ecs_service"]
+			got, err := plan.GetComputeResource(*resource, &serviceMapping, nil)
+			assert.NoError(t, err)
+			assert.Len(t, got, 1)
+			assert.Equal(t, tt.want, got[0])
+		})
+	}
+}
+
+func TestGetResource_ECSOnEC2(t *testing.T) {
+	mapping, err := plan.GetMapping()
+	assert.NoError(t, err)
+	plan.TfPlan = &map[string]interface{}{
+		"planned_values": map[string]interface{}{
+			"root_module": map[string]interface{}{
+				"resources": []interface{}{
+					map[string]interface{}{
+						"address": "aws_ecs_task_definition.api",
+						"type":    "aws_ecs_task_definition",
+						"values": map[string]interface{}{
+							"cpu":    "1024",
+							"memory": "2048",
+						},
+					},
+					map[string]interface{}{
+						"address": "aws_ecs_task_definition.worker",
+						"type":    "aws_ecs_task_definition",
+						"values": map[string]interface{}{
+							"container_definitions": `[{"name":"worker","cpu":256,"memory":512},{"name":"sidecar","cpu":256,"memoryReservation":256}]`,
+						},
+					},
+				},
+			},
+		},
+		"configuration": map[string]interface{}{
+			"provider_config": map[string]interface{}{
+				"aws": map[string]interface{}{
+					"expressions": map[string]interface{}{
+						"region": map[string]interface{}{"constant_value": "eu-west-3"},
+					},
+				},
+			},
+			"root_module": map[string]interface{}{
+				"resources": []interface{}{
+					map[string]interface{}{
+						"address": "aws_ecs_service.api",
+						"type":    "aws_ecs_service",
+						"expressions": map[string]interface{}{
+							"task_definition": map[string]interface{}{
+								"references": []interface{}{"aws_ecs_task_definition.api.arn", "aws_ecs_task_definition.api"},
+							},
+							"capacity_provider_strategy": []interface{}{
+								map[string]interface{}{
+									"capacity_provider": map[string]interface{}{
+										"references": []interface{}{"aws_ecs_capacity_provider.ec2.name", "aws_ecs_capacity_provider.ec2"},
+									},
+								},
+							},
+						},
+					},
+					map[string]interface{}{
+						"address": "aws_ecs_capacity_provider.ec2",
+						"type":    "aws_ecs_capacity_provider",
+						"expressions": map[string]interface{}{
+							"auto_scaling_group_provider": []interface{}{
+								map[string]interface{}{
+									"auto_scaling_group_arn": map[string]interface{}{
+										"references": []interface{}{"aws_autoscaling_group.capacity.arn", "aws_autoscaling_group.capacity"},
+									},
+								},
+							},
+						},
+					},
+					map[string]interface{}{
+						"address": "aws_ecs_service.worker",
+						"type":    "aws_ecs_service",
+						"expressions": map[string]interface{}{
+							"task_definition": map[string]interface{}{
+								"references": []interface{}{"aws_ecs_task_definition.worker.arn", "aws_ecs_task_definition.worker"},
+							},
+							"cluster": map[string]interface{}{
+								"references": []interface{}{"aws_ecs_cluster.main.id", "aws_ecs_cluster.main"},
+							},
+						},
+					},
+					map[string]interface{}{
+						"address": "aws_launch_template.ecs",
+						"type":    "aws_launch_template",
+						"expressions": map[string]interface{}{
+							"user_data": map[string]interface{}{
+								"references": []interface{}{"aws_ecs_cluster.main.name", "aws_ecs_cluster.main"},
+							},
+						},
+					},
+					map[string]interface{}{
+						"address": "aws_autoscaling_group.ecs",
+						"type":    "aws_autoscaling_group",
+						"expressions": map[string]interface{}{
+							"launch_template": []interface{}{
+								map[string]interface{}{
+									"id": map[string]interface{}{
+										"references": []interface{}{"aws_launch_template.ecs.id", "aws_launch_template.ecs"},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	defer func() { plan.TfPlan = &map[string]interface{}{} }()
+
+	tests := []struct {
+		name   string
+		values map[string]interface{}
+		want   resources.WorkloadResource
+	}{
+		{
+			name: "api",
+			values: map[string]interface{}{
+				"desired_count": 2,
+			},
+			want: resources.WorkloadResource{
+				Identification: &resources.ResourceIdentification{
+					Address:           "aws_ecs_service.api",
+					Name:              "api",
+					ResourceType:      "aws_ecs_service",
+					Provider:          providers.AWS,
+					Region:            "eu-west-3",
+					Count:             2,
+					ReplicationFactor: 1,
+				},
+				HostAddress: "aws_autoscaling_group.capacity",
+				VCPUs:       decimal.NewFromInt(1),
+				MemoryMb:    decimal.NewFromInt(2048),
+			},
+		},
+		{
+			name: "worker",
+			values: map[string]interface{}{
+				"launch_type":   "EC2",
+				"desired_count": 1,
+			},
+			want: resources.WorkloadResource{
+				Identification: &resources.ResourceIdentification{
+					Address:           "aws_ecs_service.worker",
+					Name:              "worker",
+					ResourceType:      "aws_ecs_service",
+					Provider:          providers.AWS,
+					Region:            "eu-west-3",
+					Count:             1,
+					ReplicationFactor: 1,
+				},
+				HostAddress: "aws_autoscaling_group.ecs",
+				VCPUs:       decimal.NewFromFloat(0.5),
+				MemoryMb:    decimal.NewFromInt(768),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tfResource := tfjson.StateResource{
+				Address:         "aws_ecs_service." + tt.name,
+				Type:            "aws_ecs_service",
+				Name:            tt.name,
+				ProviderName:    "registry.terraform.io/hashicorp/aws",
+				AttributeValues: tt.values,
+			}
+			resource, _ := testutils.TfResourceToJSON(&tfResource)
+			serviceMapping := (*mapping.ComputeResource)["aws_ecs_service_ec2"]
+			got, err := plan.GetWorkloadResource(*resource, &serviceMapping, nil)
+			assert.NoError(t, err)
+			assert.Len(t, got, 1)
+			assert.Equal(t, tt.want, got[0])
+		})
+	}
+}
@@ -0,0 +1,237 @@
+package plan_test
+
+import (
+	"testing"
+
+	"github.com/carboniferio/carbonifer/internal/plan"
+	"github.com/carboniferio/carbonifer/internal/providers"
+	"github.com/carboniferio/carbonifer/internal/resources"
+	"github.com/carboniferio/carbonifer/internal/testutils"
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetResource_RDSCluster(t *testing.T) {
+	mapping, err := plan.GetMapping()
+	assert.NoError(t, err)
+	plan.TfPlan = &map[string]interface{}{
+		"planned_values": map[string]interface{}{
+			"root_module": map[string]interface{}{
+				"resources": []interface{}{
+					map[string]interface{}{
+						"address": "aws_rds_cluster.aurora",
+						"type":    "aws_rds_cluster",
+						"values": map[string]interface{}{
+							"engine": "aurora-postgresql",
+							"serverlessv2_scaling_configuration": []interface{}{
+								map[string]interface{}{"min_capacity": 0.5, "max_capacity": 8.5},
+							},
+						},
+					},
+				},
+			},
+		},
+		"configuration": map[string]interface{}{
+			"provider_config": map[string]interface{}{
+				"aws": map[string]interface{}{
+					"expressions": map[string]interface{}{
+						"region": map[string]interface{}{"constant_value": "eu-west-3"},
+					},
+				},
+			},
+			"root_module": map[string]interface{}{
+				"resources": []interface{}{
+					map[string]interface{}{
+						"address": "aws_rds_cluster_instance.serverless",
+						"expressions": map[string]interface{}{
+							"cluster_identifier": map[string]interface{}{
+								"references": []interface{}{"aws_rds_cluster.aurora.id", "aws_rds_cluster.aurora"},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	defer func() { plan.TfPlan = &map[string]interface{}{} }()
+
+	tests := []struct {
+		name     string
+		mapping  string
+		resource tfjson.StateResource
+		want     resources.ComputeResource
+	}{
+		{
+			name:    "aurora cluster volume",
+			mapping: "aws_rds_cluster",
+			resource: tfjson.StateResource{
+				Address:      "aws_rds_cluster.aurora",
+				Type:         "aws_rds_cluster",
+				Name:         "aurora",
+				ProviderName: "registry.terraform.io/hashicorp/aws",
+				AttributeValues: map[string]interface{}{
+					"engine":             "aurora-postgresql",
+					"availability_zones": []interface{}{"eu-west-1a", "eu-west-1b", "eu-west-1c"},
+					"tags":               map[string]interface{}{"carbonifer_stored_gb": "50"},
+				},
+			},
+			want: resources.ComputeResource{
+				Identification: &resources.ResourceIdentification{
+					Address:           "aws_rds_cluster.aurora",
+					Name:              "aurora",
+					ResourceType:      "aws_rds_cluster",
+					Provider:          providers.AWS,
+					Region:            "eu-west-1",
+					Count:             1,
+					ReplicationFactor: 1,
+				},
+				Specs: &resources.ComputeResourceSpecs{
+					HddStorage:                  decimal.Zero,
+					SsdStorage:                  decimal.NewFromInt(50),
+					SsdStorageReplicationFactor: decimal.NewFromInt(6),
+				},
+				ObjectStorage: true,
+			},
+		},
+		{
+			name:    "multi-az db cluster",
+			mapping: "aws_rds_cluster_multi_az",
+			resource: tfjson.StateResource{
+				Address:      "aws_rds_cluster.postgres",
+				Type:         "aws_rds_cluster",
+				Name:         "postgres",
+				ProviderName: "registry.terraform.io/hashicorp/aws",
+				AttributeValues: map[string]interface{}{
+					"engine":                    "postgres",
+					"db_cluster_instance_class": "db.m5.large",
+					"allocated_storage":         100,
+					"storage_type":              "io1",
+				},
+			},
+			want: resources.ComputeResource{
+				Identification: &resources.ResourceIdentification{
+					Address:           "aws_rds_cluster.postgres",
+					Name:              "postgres",
+					ResourceType:      "aws_rds_cluster",
+					Provider:          providers.AWS,
+					Region:            "eu-west-3",
+					Count:             3,
+					ReplicationFactor: 1,
+				},
+				Specs: &resources.ComputeResourceSpecs{
+					VCPUs:                       2,
+					MemoryMb:                    8192,
+					CPUType:                     "Skylake",
+					HddStorage:                  decimal.Zero,
+					SsdStorage:                  decimal.NewFromInt(100),
+					SsdStorageReplicationFactor: decimal.NewFromInt(2),
+				},
+			},
+		},
+		{
+			name:    "provisioned cluster instance",
+			mapping: "aws_rds_cluster_instance",
+			resource: tfjson.StateResource{
+				Address:      "aws_rds_cluster_instance.writer",
+				Type:         "aws_rds_cluster_instance",
+				Name:         "writer",
+				ProviderName: "registry.terraform.io/hashicorp/aws",
+				AttributeValues: map[string]interface{}{
+					"instance_class":    "db.r6g.large",
+					"availability_zone": "eu-west-3a",
+				},
+			},
+			want: resources.ComputeResource{
+				Identification: &resources.ResourceIdentification{
+					Address:           "aws_rds_cluster_instance.writer",
+					Name:              "writer",
+					ResourceType:      "aws_rds_cluster_instance",
+					Provider:          providers.AWS,
+					Region:            "eu-west-3",
+					Count:             1,
+					ReplicationFactor: 1,
+				},
+				Specs: &resources.ComputeResourceSpecs{
+					VCPUs:      2,
+					MemoryMb:   16384,
+					CPUType:    "Graviton2",
+					HddStorage: decimal.Zero,
+					SsdStorage: decimal.Zero,
+				},
+			},
+		},
+		{
+			name:    "serverless v2 cluster instance",
+			mapping: "aws_rds_cluster_instance",
+			resource: tfjson.StateResource{
+				Address:      "aws_rds_cluster_instance.serverless",
+				Type:         "aws_rds_cluster_instance",
+				Name:         "serverless",
+				ProviderName: "registry.terraform.io/hashicorp/aws",
+				AttributeValues: map[string]interface{}{
+					"instance_class": "db.serverless",
+				},
+			},
+			want: resources.ComputeResource{
+				Identification: &resources.ResourceIdentification{
+					Address:           "aws_rds_cluster_instance.serverless",
+					Name:              "serverless",
+					ResourceType:      "aws_rds_cluster_instance",
+					Provider:          providers.AWS,
+					Region:            "eu-west-3",
+					Count:             1,
+					ReplicationFactor: 1,
+				},
+				Specs: &resources.ComputeResourceSpecs{
+					FractionalVCPUs: decimal.RequireFromString("1.125"),
+					MemoryMb:        9216,
+					HddStorage:      decimal.Zero,
+					SsdStorage:      decimal.Zero,
+				},
+			},
+		},
+		{
+			name:    "serverless v2 instance of a cluster outside of the plan",
+			mapping: "aws_rds_cluster_instance",
+			resource: tfjson.StateResource{
+				Address:      "aws_rds_cluster_instance.external",
+				Type:         "aws_rds_cluster_instance",
+				Name:         "external",
+				ProviderName: "registry.terraform.io/hashicorp/aws",
+				AttributeValues: map[string]interface{}{
+					"instance_class":     "db.serverless",
+					"cluster_identifier": "shared-aurora",
+				},
+			},
+			want: resources.ComputeResource{
+				Identification: &resources.ResourceIdentification{
+					Address:           "aws_rds_cluster_instance.external",
+					Name:              "external",
+					ResourceType:      "aws_rds_cluster_instance",
+					Provider:          providers.AWS,
+					Region:            "eu-west-3",
+					Count:             1,
+					ReplicationFactor: 1,
+				},
+				// Default range of 0.5 to 16 ACUs
+				Specs: &resources.ComputeResourceSpecs{
+					FractionalVCPUs: decimal.RequireFromString("2.0625"),
+					MemoryMb:        16896,
+					HddStorage:      decimal.Zero,
+					SsdStorage:      decimal.Zero,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resource, _ := testutils.TfResourceToJSON(&tt.resource)
+			resourceMapping := (*mapping.ComputeResource)[tt.mapping]
+			got, err := plan.GetComputeResource(*resource, &resourceMapping, nil)
+			assert.NoError(t, err)
+			assert.Len(t, got, 1)
+			assert.Equal(t, tt.want, got[0])
+		})
+	}
+}
@@ -38,8 +38,11 @@ func TestGetResource_RDS(t *testing.T) {
 			Specs: &resources.ComputeResourceSpecs{
 				VCPUs:      int32(2),
 				MemoryMb:   int32(8192),
+				CPUType:    "Haswell",
 				HddStorage: decimal.Zero,
 				SsdStorage: decimal.NewFromInt(300),
+
+				SsdStorageReplicationFactor: decimal.NewFromInt(2),
 			},
 		},
 		"aws_db_instance.second": resources.ComputeResource{
@@ -55,8 +58,11 @@ func TestGetResource_RDS(t *testing.T) {
 			Specs: &resources.ComputeResourceSpecs{
 				VCPUs:      int32(2),
 				MemoryMb:   int32(8192),
+				CPUType:    "Haswell",
 				HddStorage: decimal.Zero,
 				SsdStorage: decimal.NewFromInt(200),
+
+				SsdStorageReplicationFactor: decimal.NewFromInt(2),
 			},
 		},
 		"aws_db_instance.third": resources.ComputeResource{
@@ -72,8 +78,11 @@ func TestGetResource_RDS(t *testing.T) {
 			Specs: &resources.ComputeResourceSpecs{
 				VCPUs:      int32(2),
 				MemoryMb:   int32(8192),
+				CPUType:    "Haswell",
 				HddStorage: decimal.Zero,
 				SsdStorage: decimal.NewFromInt(300),
+
+				SsdStorageReplicationFactor: decimal.NewFromInt(2),
 			},
 		},
 	}
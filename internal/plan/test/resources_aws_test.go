@@ -9,6 +9,7 @@ import (
 	"github.com/carboniferio/carbonifer/internal/resources"
 	"github.com/carboniferio/carbonifer/internal/terraform"
 	"github.com/carboniferio/carbonifer/internal/testutils"
+	tfjson "github.com/hashicorp/terraform-json"
 	"github.com/shopspring/decimal"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
@@ -38,9 +39,12 @@ func TestGetResource_EC2(t *testing.T) {
 			Specs: &resources.ComputeResourceSpecs{
 				VCPUs:    int32(4),
 				MemoryMb: int32(16384),
+				CPUType:  "Skylake",
 
-				HddStorage: decimal.NewFromInt(80),
-				SsdStorage: decimal.NewFromInt(330),
+				HddStorage:                  decimal.NewFromInt(80),
+				SsdStorage:                  decimal.NewFromInt(330),
+				HddStorageReplicationFactor: decimal.NewFromInt(2),
+				SsdStorageReplicationFactor: decimal.NewFromInt(360).Div(decimal.NewFromInt(330)),
 			},
 		},
 		"aws_ebs_volume.ebs_volume": resources.ComputeResource{
@@ -54,8 +58,9 @@ func TestGetResource_EC2(t *testing.T) {
 				ReplicationFactor: 1,
 			},
 			Specs: &resources.ComputeResourceSpecs{
-				HddStorage: decimal.Zero,
-				SsdStorage: decimal.NewFromInt(100),
+				HddStorage:                  decimal.Zero,
+				SsdStorage:                  decimal.NewFromInt(100),
+				SsdStorageReplicationFactor: decimal.NewFromInt(2),
 			},
 		},
 		"aws_network_interface.foo": resources.UnsupportedResource{
@@ -89,9 +94,11 @@ func TestGetResource_EC2(t *testing.T) {
 			Specs: &resources.ComputeResourceSpecs{
 				VCPUs:    int32(4),
 				MemoryMb: int32(16384),
+				CPUType:  "Skylake",
 
-				HddStorage: decimal.Zero,
-				SsdStorage: decimal.NewFromInt(180),
+				HddStorage:                  decimal.Zero,
+				SsdStorage:                  decimal.NewFromInt(180),
+				SsdStorageReplicationFactor: decimal.NewFromInt(210).Div(decimal.NewFromInt(180)),
 			},
 		},
 		"aws_instance.ec2_with_lt_disk_override": resources.ComputeResource{
@@ -107,9 +114,11 @@ func TestGetResource_EC2(t *testing.T) {
 			Specs: &resources.ComputeResourceSpecs{
 				VCPUs:    int32(4),
 				MemoryMb: int32(16384),
+				CPUType:  "Skylake",
 
-				HddStorage: decimal.NewFromInt(300),
-				SsdStorage: decimal.NewFromInt(150),
+				HddStorage:                  decimal.NewFromInt(300),
+				SsdStorage:                  decimal.NewFromInt(150),
+				HddStorageReplicationFactor: decimal.NewFromInt(2),
 			},
 		},
 	}
@@ -122,3 +131,579 @@ func TestGetResource_EC2(t *testing.T) {
 
 	}
 }
+
+func TestGetResource_EC2CPUPlatform(t *testing.T) {
+	mapping, err := plan.GetMapping()
+	assert.NoError(t, err)
+	plan.TfPlan = &map[string]interface{}{}
+
+	tests := []struct {
+		instanceType string
+		want         string
+	}{
+		{"m6i.large", "Ice Lake"},
+		{"m7g.large", "Graviton3"},
+		{"m1.small", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.instanceType, func(t *testing.T) {
+			tfResource := tfjson.StateResource{
+				Address:      "aws_instance.foo",
+				Type:         "aws_instance",
+				Name:         "foo",
+				ProviderName: "registry.terraform.io/hashicorp/aws",
+				AttributeValues: map[string]interface{}{
+					"instance_type":     tt.instanceType,
+					"availability_zone": "eu-west-3a",
+				},
+			}
+			resource, _ := testutils.TfResourceToJSON(&tfResource)
+			awsInstanceMapping := (*mapping.ComputeResource)["aws_instance"]
+			got, err := plan.GetComputeResource(*resource, &awsInstanceMapping, nil)
+			assert.NoError(t, err)
+			assert.Len(t, got, 1)
+			assert.Equal(t, tt.want, got[0].(resources.ComputeResource).Specs.CPUType)
+		})
+	}
+}
+
+func TestGetResource_EC2GPU(t *testing.T) {
+	mapping, err := plan.GetMapping()
+	assert.NoError(t, err)
+	plan.TfPlan = &map[string]interface{}{}
+
+	tests := []struct {
+		instanceType string
+		want         []string
+	}{
+		{"g4dn.12xlarge", []string{"T4", "T4", "T4", "T4"}},
+		{"p3.2xlarge", []string{"V100"}},
+		{"m6i.large", nil},
+		{"unknown.large", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.instanceType, func(t *testing.T) {
+			tfResource := tfjson.StateResource{
+				Address:      "aws_instance.foo",
+				Type:         "aws_instance",
+				Name:         "foo",
+				ProviderName: "registry.terraform.io/hashicorp/aws",
+				AttributeValues: map[string]interface{}{
+					"instance_type":     tt.instanceType,
+					"availability_zone": "eu-west-3a",
+				},
+			}
+			resource, _ := testutils.TfResourceToJSON(&tfResource)
+			awsInstanceMapping := (*mapping.ComputeResource)["aws_instance"]
+			got, err := plan.GetComputeResource(*resource, &awsInstanceMapping, nil)
+			assert.NoError(t, err)
+			assert.Len(t, got, 1)
+			assert.Equal(t, tt.want, got[0].(resources.ComputeResource).Specs.GpuTypes)
+		})
+	}
+}
+
+func TestGetResource_EC2Lifecycle(t *testing.T) {
+	mapping, err := plan.GetMapping()
+	assert.NoError(t, err)
+	plan.TfPlan = &map[string]interface{}{}
+
+	tests := []struct {
+		name          string
+		resourceType  string
+		marketOptions interface{}
+		want          string
+	}{
+		{"on-demand", "aws_instance", nil, ""},
+		{"market options spot", "aws_instance", []interface{}{map[string]interface{}{"market_type": "spot"}}, resources.LifecycleSpot},
+		{"spot instance request", "aws_spot_instance_request", nil, resources.LifecycleSpot},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tfResource := tfjson.StateResource{
+				Address:      tt.resourceType + ".foo",
+				Type:         tt.resourceType,
+				Name:         "foo",
+				ProviderName: "registry.terraform.io/hashicorp/aws",
+				AttributeValues: map[string]interface{}{
+					"instance_type":           "m5.large",
+					"availability_zone":       "eu-west-3a",
+					"instance_market_options": tt.marketOptions,
+				},
+			}
+			resource, _ := testutils.TfResourceToJSON(&tfResource)
+			awsInstanceMapping := (*mapping.ComputeResource)["aws_instance"]
+			got, err := plan.GetComputeResource(*resource, &awsInstanceMapping, nil)
+			assert.NoError(t, err)
+			assert.Len(t, got, 1)
+			assert.Equal(t, tt.want, got[0].GetIdentification().Lifecycle)
+		})
+	}
+}
+
+func TestGetResource_EKSNodeGroup(t *testing.T) {
+	mapping, err := plan.GetMapping()
+	assert.NoError(t, err)
+	plan.TfPlan = &map[string]interface{}{
+		"planned_values": map[string]interface{}{
+			"root_module": map[string]interface{}{
+				"resources": []interface{}{
+					map[string]interface{}{
+						"address": "aws_launch_template.nodes",
+						"type":    "aws_launch_template",
+						"values": map[string]interface{}{
+							"instance_type": "c6i.xlarge",
+							"block_device_mappings": []interface{}{
+								map[string]interface{}{
+									"device_name": "/dev/xvda",
+									"ebs": []interface{}{
+										map[string]interface{}{
+											"volume_size": 50,
+											"volume_type": "gp3",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		"configuration": map[string]interface{}{
+			"provider_config": map[string]interface{}{
+				"aws": map[string]interface{}{
+					"expressions": map[string]interface{}{
+						"region": map[string]interface{}{"constant_value": "eu-west-3"},
+					},
+				},
+			},
+			"root_module": map[string]interface{}{
+				"resources": []interface{}{
+					map[string]interface{}{
+						"address": "aws_eks_node_group.lt",
+						"expressions": map[string]interface{}{
+							"launch_template": []interface{}{
+								map[string]interface{}{
+									"id": map[string]interface{}{
+										"references": []interface{}{"aws_launch_template.nodes.id", "aws_launch_template.nodes"},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	defer func() { plan.TfPlan = &map[string]interface{}{} }()
+
+	tests := []struct {
+		name   string
+		values map[string]interface{}
+		want   resources.ComputeResource
+	}{
+		{
+			name: "default",
+			values: map[string]interface{}{
+				"scaling_config": []interface{}{
+					map[string]interface{}{"desired_size": 2, "min_size": 1, "max_size": 5},
+				},
+			},
+			want: resources.ComputeResource{
+				Identification: &resources.ResourceIdentification{
+					Address:           "aws_eks_node_group.default",
+					Name:              "default",
+					ResourceType:      "aws_eks_node_group",
+					Provider:          providers.AWS,
+					Region:            "eu-west-3",
+					Count:             3,
+					ReplicationFactor: 1,
+				},
+				Specs: &resources.ComputeResourceSpecs{
+					VCPUs:                       2,
+					MemoryMb:                    4096,
+					CPUType:                     "Skylake",
+					HddStorage:                  decimal.Zero,
+					SsdStorage:                  decimal.NewFromInt(20),
+					SsdStorageReplicationFactor: decimal.NewFromInt(2),
+				},
+			},
+		},
+		{
+			name: "spot",
+			values: map[string]interface{}{
+				"instance_types": []interface{}{"g4dn.xlarge", "g4dn.2xlarge"},
+				"capacity_type":  "SPOT",
+				"disk_size":      100,
+				"scaling_config": []interface{}{
+					map[string]interface{}{"desired_size": 2},
+				},
+			},
+			want: resources.ComputeResource{
+				Identification: &resources.ResourceIdentification{
+					Address:           "aws_eks_node_group.spot",
+					Name:              "spot",
+					ResourceType:      "aws_eks_node_group",
+					Provider:          providers.AWS,
+					Region:            "eu-west-3",
+					Count:             2,
+					ReplicationFactor: 1,
+					Lifecycle:         resources.LifecycleSpot,
+				},
+				Specs: &resources.ComputeResourceSpecs{
+					VCPUs:                       4,
+					MemoryMb:                    16384,
+					CPUType:                     "Cascade Lake",
+					GpuTypes:                    []string{"T4"},
+					HddStorage:                  decimal.Zero,
+					SsdStorage:                  decimal.NewFromInt(100),
+					SsdStorageReplicationFactor: decimal.NewFromInt(2),
+				},
+				// Spot nodes are spread over all the instance types
+				MixedInstances: &resources.MixedInstances{
+					InstanceTypes: []resources.MixedInstanceType{
+						{
+							InstanceType:     "g4dn.xlarge",
+							WeightedCapacity: decimal.NewFromInt(1),
+							Specs: &resources.ComputeResourceSpecs{
+								VCPUs:      4,
+								MemoryMb:   16384,
+								CPUType:    "Cascade Lake",
+								GpuTypes:   []string{"T4"},
+								HddStorage: decimal.Zero,
+								SsdStorage: decimal.Zero,
+							},
+						},
+						{
+							InstanceType:     "g4dn.2xlarge",
+							WeightedCapacity: decimal.NewFromInt(1),
+							Specs: &resources.ComputeResourceSpecs{
+								VCPUs:      8,
+								MemoryMb:   32768,
+								CPUType:    "Cascade Lake",
+								GpuTypes:   []string{"T4"},
+								HddStorage: decimal.Zero,
+								SsdStorage: decimal.Zero,
+							},
+						},
+					},
+					OnDemandPercentageAboveBaseCapacity: decimal.NewFromInt(0),
+				},
+			},
+		},
+		{
+			name: "lt",
+			values: map[string]interface{}{
+				"launch_template": []interface{}{
+					map[string]interface{}{"version": "1"},
+				},
+				"scaling_config": []interface{}{
+					map[string]interface{}{"desired_size": 3, "min_size": 3, "max_size": 3},
+				},
+			},
+			want: resources.ComputeResource{
+				Identification: &resources.ResourceIdentification{
+					Address:           "aws_eks_node_group.lt",
+					Name:              "lt",
+					ResourceType:      "aws_eks_node_group",
+					Provider:          providers.AWS,
+					Region:            "eu-west-3",
+					Count:             3,
+					ReplicationFactor: 1,
+				},
+				Specs: &resources.ComputeResourceSpecs{
+					VCPUs:                       4,
+					MemoryMb:                    8192,
+					CPUType:                     "Ice Lake",
+					HddStorage:                  decimal.Zero,
+					SsdStorage:                  decimal.NewFromInt(50),
+					SsdStorageReplicationFactor: decimal.NewFromInt(2),
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tfResource := tfjson.StateResource{
+				Address:         "aws_eks_node_group." + tt.name,
+				Type:            "aws_eks_node_group",
+				Name:            tt.name,
+				ProviderName:    "registry.terraform.io/hashicorp/aws",
+				AttributeValues: tt.values,
+			}
+			resource, _ := testutils.TfResourceToJSON(&tfResource)
+			nodeGroupMapping := (*mapping.ComputeResource)["aws_eks_node_group"]
+			got, err := plan.GetComputeResource(*resource, &nodeGroupMapping, nil)
+			assert.NoError(t, err)
+			assert.Len(t, got, 1)
+			assert.Equal(t, tt.want, got[0])
+		})
+	}
+}
+
+func TestGetResource_EKSNodeGroupInModule(t *testing.T) {
+	mapping, err := plan.GetMapping()
+	assert.NoError(t, err)
+
+	// Layout of terraform-aws-modules/eks: node groups in a module called with for_each in the module of the cluster,
+	// their launch template set from a local and their cluster from a variable
+	nodeGroups := map[string]map[string]interface{}{}
+	launchTemplates := map[string]map[string]interface{}{}
+	childModules := []interface{}{}
+	for name, instanceType := range map[string]string{"default": "m6i.large", "gpu": "g5.xlarge"} {
+		moduleAddress := `module.eks.module.eks_managed_node_group["` + name + `"]`
+		nodeGroups[name] = map[string]interface{}{
+			"address":       moduleAddress + ".aws_eks_node_group.this[0]",
+			"type":          "aws_eks_node_group",
+			"name":          "this",
+			"index":         float64(0),
+			"provider_name": "registry.terraform.io/hashicorp/aws",
+			"values": map[string]interface{}{
+				"launch_template": []interface{}{
+					map[string]interface{}{"version": "1"},
+				},
+				"scaling_config": []interface{}{
+					map[string]interface{}{"desired_size": 2},
+				},
+			},
+		}
+		launchTemplates[name] = map[string]interface{}{
+			"address": moduleAddress + ".aws_launch_template.this[0]",
+			"type":    "aws_launch_template",
+			"name":    "this",
+			"index":   float64(0),
+			"values": map[string]interface{}{
+				"instance_type": instanceType,
+				"block_device_mappings": []interface{}{
+					map[string]interface{}{
+						"device_name": "/dev/xvda",
+						"ebs": []interface{}{
+							map[string]interface{}{
+								"volume_size": 40,
+								"volume_type": "gp3",
+							},
+						},
+					},
+				},
+			},
+		}
+		childModules = append(childModules, map[string]interface{}{
+			"address":   moduleAddress,
+			"resources": []interface{}{nodeGroups[name], launchTemplates[name]},
+		})
+	}
+	plan.TfPlan = &map[string]interface{}{
+		"planned_values": map[string]interface{}{
+			"root_module": map[string]interface{}{
+				"child_modules": []interface{}{
+					map[string]interface{}{
+						"address": "module.eks",
+						"resources": []interface{}{
+							map[string]interface{}{
+								"address": "module.eks.aws_eks_cluster.this[0]",
+								"type":    "aws_eks_cluster",
+								"name":    "this",
+								"index":   float64(0),
+							},
+						},
+						"child_modules": childModules,
+					},
+				},
+			},
+		},
+		"configuration": map[string]interface{}{
+			"provider_config": map[string]interface{}{
+				"aws": map[string]interface{}{
+					"expressions": map[string]interface{}{
+						"region": map[string]interface{}{"constant_value": "eu-west-3"},
+					},
+				},
+			},
+			"root_module": map[string]interface{}{
+				"module_calls": map[string]interface{}{
+					"eks": map[string]interface{}{
+						"module": map[string]interface{}{
+							"resources": []interface{}{
+								map[string]interface{}{"address": "aws_eks_cluster.this"},
+							},
+							"module_calls": map[string]interface{}{
+								"eks_managed_node_group": map[string]interface{}{
+									"expressions": map[string]interface{}{
+										"cluster_name": map[string]interface{}{
+											"references": []interface{}{"aws_eks_cluster.this[0].name", "aws_eks_cluster.this[0]", "aws_eks_cluster.this"},
+										},
+									},
+									"module": map[string]interface{}{
+										"resources": []interface{}{
+											map[string]interface{}{
+												"address": "aws_eks_node_group.this",
+												"expressions": map[string]interface{}{
+													"cluster_name": map[string]interface{}{
+														"references": []interface{}{"var.cluster_name"},
+													},
+													"launch_template": []interface{}{
+														map[string]interface{}{
+															"id": map[string]interface{}{
+																"references": []interface{}{"local.launch_template_id"},
+															},
+														},
+													},
+												},
+											},
+											map[string]interface{}{"address": "aws_launch_template.this"},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	defer func() { plan.TfPlan = &map[string]interface{}{} }()
+
+	tests := []struct {
+		name string
+		want resources.ComputeResource
+	}{
+		{
+			name: "default",
+			want: resources.ComputeResource{
+				Identification: &resources.ResourceIdentification{
+					Address:           `module.eks.module.eks_managed_node_group["default"].aws_eks_node_group.this[0]`,
+					Name:              "this[0]",
+					ResourceType:      "aws_eks_node_group",
+					Provider:          providers.AWS,
+					Region:            "eu-west-3",
+					Count:             2,
+					ReplicationFactor: 1,
+				},
+				Specs: &resources.ComputeResourceSpecs{
+					VCPUs:                       2,
+					MemoryMb:                    8192,
+					CPUType:                     "Ice Lake",
+					HddStorage:                  decimal.Zero,
+					SsdStorage:                  decimal.NewFromInt(40),
+					SsdStorageReplicationFactor: decimal.NewFromInt(2),
+				},
+				Cluster: "module.eks.aws_eks_cluster.this[0]",
+			},
+		},
+		{
+			name: "gpu",
+			want: resources.ComputeResource{
+				Identification: &resources.ResourceIdentification{
+					Address:           `module.eks.module.eks_managed_node_group["gpu"].aws_eks_node_group.this[0]`,
+					Name:              "this[0]",
+					ResourceType:      "aws_eks_node_group",
+					Provider:          providers.AWS,
+					Region:            "eu-west-3",
+					Count:             2,
+					ReplicationFactor: 1,
+				},
+				Specs: &resources.ComputeResourceSpecs{
+					VCPUs:                       4,
+					MemoryMb:                    16384,
+					CPUType:                     "EPYC 2nd Gen",
+					GpuTypes:                    []string{"A10G"},
+					HddStorage:                  decimal.Zero,
+					SsdStorage:                  decimal.NewFromInt(40),
+					SsdStorageReplicationFactor: decimal.NewFromInt(2),
+				},
+				Cluster: "module.eks.aws_eks_cluster.this[0]",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nodeGroupMapping := (*mapping.ComputeResource)["aws_eks_node_group"]
+			got, err := plan.GetComputeResource(nodeGroups[tt.name], &nodeGroupMapping, nil)
+			assert.NoError(t, err)
+			assert.Len(t, got, 1)
+			assert.Equal(t, tt.want, got[0])
+		})
+	}
+}
+
+func TestGetResource_ASGMixedInstances(t *testing.T) {
+	mapping, err := plan.GetMapping()
+	assert.NoError(t, err)
+	plan.TfPlan = &map[string]interface{}{
+		"configuration": map[string]interface{}{
+			"provider_config": map[string]interface{}{
+				"aws": map[string]interface{}{
+					"expressions": map[string]interface{}{
+						"region": map[string]interface{}{"constant_value": "eu-west-3"},
+					},
+				},
+			},
+		},
+	}
+	defer func() { plan.TfPlan = &map[string]interface{}{} }()
+
+	tfResource := tfjson.StateResource{
+		Address:      "aws_autoscaling_group.mixed",
+		Type:         "aws_autoscaling_group",
+		Name:         "mixed",
+		ProviderName: "registry.terraform.io/hashicorp/aws",
+		AttributeValues: map[string]interface{}{
+			"min_size": 2,
+			"max_size": 6,
+			"mixed_instances_policy": []interface{}{
+				map[string]interface{}{
+					"instances_distribution": []interface{}{
+						map[string]interface{}{
+							"on_demand_base_capacity":                  1,
+							"on_demand_percentage_above_base_capacity": 25,
+						},
+					},
+					"launch_template": []interface{}{
+						map[string]interface{}{
+							"override": []interface{}{
+								map[string]interface{}{"instance_type": "m5.large"},
+								map[string]interface{}{"instance_type": "g4dn.xlarge", "weighted_capacity": "2"},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	resource, _ := testutils.TfResourceToJSON(&tfResource)
+	asgMapping := (*mapping.ComputeResource)["aws_autoscaling_group"]
+	got, err := plan.GetComputeResource(*resource, &asgMapping, nil)
+	assert.NoError(t, err)
+	assert.Len(t, got, 1)
+	gotResource := got[0].(resources.ComputeResource)
+	assert.Equal(t, int64(4), gotResource.Identification.Count)
+	assert.Equal(t, &resources.MixedInstances{
+		InstanceTypes: []resources.MixedInstanceType{
+			{
+				InstanceType:     "m5.large",
+				WeightedCapacity: decimal.NewFromInt(1),
+				Specs: &resources.ComputeResourceSpecs{
+					VCPUs:      2,
+					MemoryMb:   8192,
+					CPUType:    "Skylake",
+					HddStorage: decimal.Zero,
+					SsdStorage: decimal.Zero,
+				},
+			},
+			{
+				InstanceType:     "g4dn.xlarge",
+				WeightedCapacity: decimal.NewFromInt(2),
+				Specs: &resources.ComputeResourceSpecs{
+					VCPUs:      4,
+					MemoryMb:   16384,
+					CPUType:    "Cascade Lake",
+					GpuTypes:   []string{"T4"},
+					HddStorage: decimal.Zero,
+					SsdStorage: decimal.Zero,
+				},
+			},
+		},
+		OnDemandBaseCapacity:                1,
+		OnDemandPercentageAboveBaseCapacity: decimal.NewFromInt(25),
+	}, gotResource.MixedInstances)
+}
@@ -0,0 +1,546 @@
+package plan_test
+
+import (
+	"testing"
+
+	"github.com/carboniferio/carbonifer/internal/plan"
+	"github.com/carboniferio/carbonifer/internal/providers"
+	"github.com/carboniferio/carbonifer/internal/resources"
+	"github.com/carboniferio/carbonifer/internal/testutils"
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetResource_Azure(t *testing.T) {
+	mapping, err := plan.GetMapping()
+	assert.NoError(t, err)
+	plan.TfPlan = &map[string]interface{}{}
+
+	tests := []struct {
+		name       string
+		tfResource tfjson.StateResource
+		mapping    string
+		want       resources.ComputeResource
+	}{
+		{
+			name: "linux vm",
+			tfResource: tfjson.StateResource{
+				Address:      "azurerm_linux_virtual_machine.vm",
+				Type:         "azurerm_linux_virtual_machine",
+				Name:         "vm",
+				ProviderName: "registry.terraform.io/hashicorp/azurerm",
+				AttributeValues: map[string]interface{}{
+					"size":     "Standard_D4s_v3",
+					"location": "West Europe",
+					"os_disk": []interface{}{
+						map[string]interface{}{
+							"storage_account_type": "Premium_LRS",
+							"disk_size_gb":         64,
+						},
+					},
+				},
+			},
+			mapping: "azurerm_linux_virtual_machine",
+			want: resources.ComputeResource{
+				Identification: &resources.ResourceIdentification{
+					Address:           "azurerm_linux_virtual_machine.vm",
+					Name:              "vm",
+					ResourceType:      "azurerm_linux_virtual_machine",
+					Provider:          providers.AZURE,
+					Region:            "westeurope",
+					Count:             1,
+					ReplicationFactor: 1,
+				},
+				Specs: &resources.ComputeResourceSpecs{
+					VCPUs:                       4,
+					MemoryMb:                    16384,
+					CPUType:                     "Broadwell",
+					HddStorage:                  decimal.Zero,
+					SsdStorage:                  decimal.NewFromInt(96),
+					SsdStorageReplicationFactor: decimal.NewFromInt(224).Div(decimal.NewFromInt(96)),
+				},
+			},
+		},
+		{
+			name: "windows spot vm with default os disk",
+			tfResource: tfjson.StateResource{
+				Address:      "azurerm_windows_virtual_machine.vm",
+				Type:         "azurerm_windows_virtual_machine",
+				Name:         "vm",
+				ProviderName: "registry.terraform.io/hashicorp/azurerm",
+				AttributeValues: map[string]interface{}{
+					"size":     "Standard_D2s_v5",
+					"location": "francecentral",
+					"priority": "Spot",
+					"os_disk": []interface{}{
+						map[string]interface{}{
+							"storage_account_type": "Standard_LRS",
+						},
+					},
+				},
+			},
+			mapping: "azurerm_linux_virtual_machine",
+			want: resources.ComputeResource{
+				Identification: &resources.ResourceIdentification{
+					Address:           "azurerm_windows_virtual_machine.vm",
+					Name:              "vm",
+					ResourceType:      "azurerm_windows_virtual_machine",
+					Provider:          providers.AZURE,
+					Region:            "francecentral",
+					Count:             1,
+					ReplicationFactor: 1,
+					Lifecycle:         resources.LifecycleSpot,
+				},
+				Specs: &resources.ComputeResourceSpecs{
+					VCPUs:      2,
+					MemoryMb:   8192,
+					CPUType:    "Ice Lake",
+					HddStorage: decimal.NewFromInt(127),
+					// Standard_D2s_v5 has no temporary disk
+					SsdStorage:                  decimal.NewFromInt(0),
+					HddStorageReplicationFactor: decimal.NewFromInt(3),
+				},
+			},
+		},
+		{
+			name: "vm of unknown size",
+			tfResource: tfjson.StateResource{
+				Address:      "azurerm_linux_virtual_machine.unknown",
+				Type:         "azurerm_linux_virtual_machine",
+				Name:         "unknown",
+				ProviderName: "registry.terraform.io/hashicorp/azurerm",
+				AttributeValues: map[string]interface{}{
+					"size":     "Standard_Foo_v9",
+					"location": "West Europe",
+					"os_disk": []interface{}{
+						map[string]interface{}{
+							"storage_account_type": "Premium_LRS",
+							"disk_size_gb":         64,
+						},
+					},
+				},
+			},
+			mapping: "azurerm_linux_virtual_machine",
+			want: resources.ComputeResource{
+				Identification: &resources.ResourceIdentification{
+					Address:           "azurerm_linux_virtual_machine.unknown",
+					Name:              "unknown",
+					ResourceType:      "azurerm_linux_virtual_machine",
+					Provider:          providers.AZURE,
+					Region:            "westeurope",
+					Count:             1,
+					ReplicationFactor: 1,
+				},
+				Specs: &resources.ComputeResourceSpecs{
+					HddStorage:                  decimal.Zero,
+					SsdStorage:                  decimal.NewFromInt(64),
+					SsdStorageReplicationFactor: decimal.NewFromInt(192).Div(decimal.NewFromInt(64)),
+				},
+			},
+		},
+		{
+			name: "gpu vm",
+			tfResource: tfjson.StateResource{
+				Address:      "azurerm_linux_virtual_machine.gpu",
+				Type:         "azurerm_linux_virtual_machine",
+				Name:         "gpu",
+				ProviderName: "registry.terraform.io/hashicorp/azurerm",
+				AttributeValues: map[string]interface{}{
+					"size":     "Standard_NC12s_v3",
+					"location": "eastus",
+					"os_disk": []interface{}{
+						map[string]interface{}{
+							"storage_account_type": "Premium_LRS",
+						},
+					},
+				},
+			},
+			mapping: "azurerm_linux_virtual_machine",
+			want: resources.ComputeResource{
+				Identification: &resources.ResourceIdentification{
+					Address:           "azurerm_linux_virtual_machine.gpu",
+					Name:              "gpu",
+					ResourceType:      "azurerm_linux_virtual_machine",
+					Provider:          providers.AZURE,
+					Region:            "eastus",
+					Count:             1,
+					ReplicationFactor: 1,
+				},
+				Specs: &resources.ComputeResourceSpecs{
+					VCPUs:                       12,
+					MemoryMb:                    229376,
+					CPUType:                     "Broadwell",
+					GpuTypes:                    []string{"NVIDIA Tesla V100", "NVIDIA Tesla V100"},
+					HddStorage:                  decimal.Zero,
+					SsdStorage:                  decimal.NewFromInt(1504),
+					SsdStorageReplicationFactor: decimal.NewFromInt(1564).Div(decimal.NewFromInt(1504)),
+				},
+			},
+		},
+		{
+			name: "managed disk",
+			tfResource: tfjson.StateResource{
+				Address:      "azurerm_managed_disk.data",
+				Type:         "azurerm_managed_disk",
+				Name:         "data",
+				ProviderName: "registry.terraform.io/hashicorp/azurerm",
+				AttributeValues: map[string]interface{}{
+					"location":             "westeurope",
+					"storage_account_type": "StandardSSD_ZRS",
+					"disk_size_gb":         512,
+				},
+			},
+			mapping: "azurerm_managed_disk",
+			want: resources.ComputeResource{
+				Identification: &resources.ResourceIdentification{
+					Address:           "azurerm_managed_disk.data",
+					Name:              "data",
+					ResourceType:      "azurerm_managed_disk",
+					Provider:          providers.AZURE,
+					Region:            "westeurope",
+					Count:             1,
+					ReplicationFactor: 1,
+				},
+				Specs: &resources.ComputeResourceSpecs{
+					HddStorage:                  decimal.Zero,
+					SsdStorage:                  decimal.NewFromInt(512),
+					SsdStorageReplicationFactor: decimal.NewFromInt(3),
+				},
+			},
+		},
+		{
+			name: "scale set",
+			tfResource: tfjson.StateResource{
+				Address:      "azurerm_linux_virtual_machine_scale_set.vmss",
+				Type:         "azurerm_linux_virtual_machine_scale_set",
+				Name:         "vmss",
+				ProviderName: "registry.terraform.io/hashicorp/azurerm",
+				AttributeValues: map[string]interface{}{
+					"sku":       "Standard_F4s_v2",
+					"location":  "northeurope",
+					"instances": 3,
+					"os_disk": []interface{}{
+						map[string]interface{}{
+							"storage_account_type": "Standard_LRS",
+						},
+					},
+					"data_disk": []interface{}{
+						map[string]interface{}{
+							"storage_account_type": "Standard_LRS",
+							"disk_size_gb":         100,
+						},
+					},
+				},
+			},
+			mapping: "azurerm_linux_virtual_machine_scale_set",
+			want: resources.ComputeResource{
+				Identification: &resources.ResourceIdentification{
+					Address:           "azurerm_linux_virtual_machine_scale_set.vmss",
+					Name:              "vmss",
+					ResourceType:      "azurerm_linux_virtual_machine_scale_set",
+					Provider:          providers.AZURE,
+					Region:            "northeurope",
+					Count:             3,
+					ReplicationFactor: 1,
+				},
+				Specs: &resources.ComputeResourceSpecs{
+					VCPUs:                       4,
+					MemoryMb:                    8192,
+					CPUType:                     "Skylake",
+					HddStorage:                  decimal.NewFromInt(130),
+					SsdStorage:                  decimal.NewFromInt(32),
+					HddStorageReplicationFactor: decimal.NewFromInt(3),
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resource, _ := testutils.TfResourceToJSON(&tt.tfResource)
+			resourceMapping := (*mapping.ComputeResource)[tt.mapping]
+			got, err := plan.GetComputeResource(*resource, &resourceMapping, nil)
+			assert.NoError(t, err)
+			assert.Len(t, got, 1)
+			assert.Equal(t, tt.want, got[0])
+		})
+	}
+}
+
+func TestGetResource_AzureKubernetesAndDatabases(t *testing.T) {
+	mapping, err := plan.GetMapping()
+	assert.NoError(t, err)
+	// Node pools and databases get their location from their cluster and server,
+	// or from the resource group when these are not in the plan
+	plan.TfPlan = &map[string]interface{}{
+		"planned_values": map[string]interface{}{
+			"root_module": map[string]interface{}{
+				"resources": []interface{}{
+					map[string]interface{}{
+						"address": "azurerm_kubernetes_cluster.aks",
+						"type":    "azurerm_kubernetes_cluster",
+						"values":  map[string]interface{}{"location": "West Europe"},
+					},
+					map[string]interface{}{
+						"address": "azurerm_mssql_server.sql",
+						"type":    "azurerm_mssql_server",
+						"values":  map[string]interface{}{"location": "northeurope"},
+					},
+					map[string]interface{}{
+						"address": "azurerm_resource_group.rg",
+						"type":    "azurerm_resource_group",
+						"values":  map[string]interface{}{"location": "France Central"},
+					},
+				},
+			},
+		},
+		"configuration": map[string]interface{}{
+			"root_module": map[string]interface{}{
+				"resources": []interface{}{
+					map[string]interface{}{
+						"address": "azurerm_kubernetes_cluster_node_pool.spot",
+						"expressions": map[string]interface{}{
+							"kubernetes_cluster_id": map[string]interface{}{
+								"references": []interface{}{"azurerm_kubernetes_cluster.aks.id", "azurerm_kubernetes_cluster.aks"},
+							},
+						},
+					},
+					map[string]interface{}{
+						"address": "azurerm_mssql_database.db",
+						"expressions": map[string]interface{}{
+							"server_id": map[string]interface{}{
+								"references": []interface{}{"azurerm_mssql_server.sql.id", "azurerm_mssql_server.sql"},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	defer func() { plan.TfPlan = &map[string]interface{}{} }()
+
+	tests := []struct {
+		name       string
+		tfResource tfjson.StateResource
+		mapping    string
+		want       resources.ComputeResource
+	}{
+		{
+			name: "aks cluster with autoscaling default node pool",
+			tfResource: tfjson.StateResource{
+				Address:      "azurerm_kubernetes_cluster.aks",
+				Type:         "azurerm_kubernetes_cluster",
+				Name:         "aks",
+				ProviderName: "registry.terraform.io/hashicorp/azurerm",
+				AttributeValues: map[string]interface{}{
+					"location": "West Europe",
+					"default_node_pool": []interface{}{
+						map[string]interface{}{
+							"vm_size":             "Standard_D4s_v3",
+							"enable_auto_scaling": true,
+							"min_count":           1,
+							"max_count":           5,
+						},
+					},
+				},
+			},
+			mapping: "azurerm_kubernetes_cluster",
+			want: resources.ComputeResource{
+				Identification: &resources.ResourceIdentification{
+					Address:           "azurerm_kubernetes_cluster.aks",
+					Name:              "aks",
+					ResourceType:      "azurerm_kubernetes_cluster",
+					Provider:          providers.AZURE,
+					Region:            "westeurope",
+					Count:             3,
+					ReplicationFactor: 1,
+				},
+				Specs: &resources.ComputeResourceSpecs{
+					VCPUs:                       4,
+					MemoryMb:                    16384,
+					CPUType:                     "Broadwell",
+					HddStorage:                  decimal.Zero,
+					SsdStorage:                  decimal.NewFromInt(160),
+					SsdStorageReplicationFactor: decimal.NewFromInt(416).Div(decimal.NewFromInt(160)),
+				},
+			},
+		},
+		{
+			name: "aks spot node pool with ephemeral os disk",
+			tfResource: tfjson.StateResource{
+				Address:      "azurerm_kubernetes_cluster_node_pool.spot",
+				Type:         "azurerm_kubernetes_cluster_node_pool",
+				Name:         "spot",
+				ProviderName: "registry.terraform.io/hashicorp/azurerm",
+				AttributeValues: map[string]interface{}{
+					"vm_size":      "Standard_E4ds_v5",
+					"node_count":   2,
+					"priority":     "Spot",
+					"os_disk_type": "Ephemeral",
+				},
+			},
+			mapping: "azurerm_kubernetes_cluster_node_pool",
+			want: resources.ComputeResource{
+				Identification: &resources.ResourceIdentification{
+					Address:           "azurerm_kubernetes_cluster_node_pool.spot",
+					Name:              "spot",
+					ResourceType:      "azurerm_kubernetes_cluster_node_pool",
+					Provider:          providers.AZURE,
+					Region:            "westeurope",
+					Count:             2,
+					ReplicationFactor: 1,
+					Lifecycle:         resources.LifecycleSpot,
+				},
+				Specs: &resources.ComputeResourceSpecs{
+					VCPUs:      4,
+					MemoryMb:   32768,
+					CPUType:    "Ice Lake",
+					HddStorage: decimal.Zero,
+					SsdStorage: decimal.NewFromInt(150),
+				},
+				Cluster: "azurerm_kubernetes_cluster.aks",
+			},
+		},
+		{
+			name: "zone-redundant postgresql flexible server",
+			tfResource: tfjson.StateResource{
+				Address:      "azurerm_postgresql_flexible_server.pg",
+				Type:         "azurerm_postgresql_flexible_server",
+				Name:         "pg",
+				ProviderName: "registry.terraform.io/hashicorp/azurerm",
+				AttributeValues: map[string]interface{}{
+					"location":   "francecentral",
+					"sku_name":   "GP_Standard_D2s_v5",
+					"storage_mb": 65536,
+					"high_availability": []interface{}{
+						map[string]interface{}{
+							"mode": "ZoneRedundant",
+						},
+					},
+				},
+			},
+			mapping: "azurerm_postgresql_flexible_server",
+			want: resources.ComputeResource{
+				Identification: &resources.ResourceIdentification{
+					Address:           "azurerm_postgresql_flexible_server.pg",
+					Name:              "pg",
+					ResourceType:      "azurerm_postgresql_flexible_server",
+					Provider:          providers.AZURE,
+					Region:            "francecentral",
+					Count:             1,
+					ReplicationFactor: 2,
+				},
+				Specs: &resources.ComputeResourceSpecs{
+					VCPUs:                       2,
+					MemoryMb:                    8192,
+					CPUType:                     "Ice Lake",
+					HddStorage:                  decimal.Zero,
+					SsdStorage:                  decimal.NewFromInt(65536).Div(decimal.NewFromInt(1024)),
+					SsdStorageReplicationFactor: decimal.NewFromInt(3),
+				},
+			},
+		},
+		{
+			name: "business critical sql database",
+			tfResource: tfjson.StateResource{
+				Address:      "azurerm_mssql_database.db",
+				Type:         "azurerm_mssql_database",
+				Name:         "db",
+				ProviderName: "registry.terraform.io/hashicorp/azurerm",
+				AttributeValues: map[string]interface{}{
+					"sku_name":    "BC_Gen5_4",
+					"max_size_gb": 100,
+				},
+			},
+			mapping: "azurerm_mssql_database",
+			want: resources.ComputeResource{
+				Identification: &resources.ResourceIdentification{
+					Address:           "azurerm_mssql_database.db",
+					Name:              "db",
+					ResourceType:      "azurerm_mssql_database",
+					Provider:          providers.AZURE,
+					Region:            "northeurope",
+					Count:             1,
+					ReplicationFactor: 4,
+				},
+				Specs: &resources.ComputeResourceSpecs{
+					VCPUs:      4,
+					MemoryMb:   20890,
+					HddStorage: decimal.Zero,
+					SsdStorage: decimal.NewFromInt(100),
+				},
+			},
+		},
+		{
+			name: "node pool of unknown size outside of the plan",
+			tfResource: tfjson.StateResource{
+				Address:      "azurerm_kubernetes_cluster_node_pool.external",
+				Type:         "azurerm_kubernetes_cluster_node_pool",
+				Name:         "external",
+				ProviderName: "registry.terraform.io/hashicorp/azurerm",
+				AttributeValues: map[string]interface{}{
+					"vm_size":    "Standard_Foo_v9",
+					"node_count": 2,
+				},
+			},
+			mapping: "azurerm_kubernetes_cluster_node_pool",
+			want: resources.ComputeResource{
+				Identification: &resources.ResourceIdentification{
+					Address:           "azurerm_kubernetes_cluster_node_pool.external",
+					Name:              "external",
+					ResourceType:      "azurerm_kubernetes_cluster_node_pool",
+					Provider:          providers.AZURE,
+					Region:            "francecentral",
+					Count:             2,
+					ReplicationFactor: 1,
+				},
+				Specs: &resources.ComputeResourceSpecs{
+					HddStorage:                  decimal.Zero,
+					SsdStorage:                  decimal.NewFromInt(128),
+					SsdStorageReplicationFactor: decimal.NewFromInt(384).Div(decimal.NewFromInt(128)),
+				},
+			},
+		},
+		{
+			name: "sql database of a server outside of the plan",
+			tfResource: tfjson.StateResource{
+				Address:      "azurerm_mssql_database.external",
+				Type:         "azurerm_mssql_database",
+				Name:         "external",
+				ProviderName: "registry.terraform.io/hashicorp/azurerm",
+				AttributeValues: map[string]interface{}{
+					"sku_name":    "GP_Gen5_2",
+					"max_size_gb": 50,
+				},
+			},
+			mapping: "azurerm_mssql_database",
+			want: resources.ComputeResource{
+				Identification: &resources.ResourceIdentification{
+					Address:           "azurerm_mssql_database.external",
+					Name:              "external",
+					ResourceType:      "azurerm_mssql_database",
+					Provider:          providers.AZURE,
+					Region:            "francecentral",
+					Count:             1,
+					ReplicationFactor: 1,
+				},
+				Specs: &resources.ComputeResourceSpecs{
+					VCPUs:      2,
+					MemoryMb:   10445,
+					HddStorage: decimal.Zero,
+					SsdStorage: decimal.NewFromInt(50),
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resource, _ := testutils.TfResourceToJSON(&tt.tfResource)
+			resourceMapping := (*mapping.ComputeResource)[tt.mapping]
+			got, err := plan.GetComputeResource(*resource, &resourceMapping, nil)
+			assert.NoError(t, err)
+			assert.Len(t, got, 1)
+			assert.Equal(t, tt.want, got[0])
+		})
+	}
+}
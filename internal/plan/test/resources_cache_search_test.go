@@ -0,0 +1,448 @@
+package plan_test
+
+import (
+	"testing"
+
+	"github.com/carboniferio/carbonifer/internal/plan"
+	"github.com/carboniferio/carbonifer/internal/providers"
+	"github.com/carboniferio/carbonifer/internal/resources"
+	"github.com/carboniferio/carbonifer/internal/testutils"
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetResource_CacheAndSearch(t *testing.T) {
+	mapping, err := plan.GetMapping()
+	assert.NoError(t, err)
+	plan.TfPlan = &map[string]interface{}{
+		"configuration": map[string]interface{}{
+			"provider_config": map[string]interface{}{
+				"aws": map[string]interface{}{
+					"expressions": map[string]interface{}{
+						"region": map[string]interface{}{"constant_value": "eu-west-3"},
+					},
+				},
+			},
+		},
+	}
+	defer func() { plan.TfPlan = &map[string]interface{}{} }()
+
+	tests := []struct {
+		name     string
+		mapping  string
+		resource tfjson.StateResource
+		want     resources.ComputeResource
+	}{
+		{
+			name:    "memorystore standard with read replicas",
+			mapping: "google_redis_instance",
+			resource: tfjson.StateResource{
+				Address:      "google_redis_instance.cache",
+				Type:         "google_redis_instance",
+				Name:         "cache",
+				ProviderName: "registry.terraform.io/hashicorp/google",
+				AttributeValues: map[string]interface{}{
+					"tier":               "STANDARD_HA",
+					"memory_size_gb":     16,
+					"location_id":        "europe-west1-b",
+					"region":             "europe-west1",
+					"read_replicas_mode": "READ_REPLICAS_ENABLED",
+					"replica_count":      2,
+				},
+			},
+			want: resources.ComputeResource{
+				Identification: &resources.ResourceIdentification{
+					Address:           "google_redis_instance.cache",
+					Name:              "cache",
+					ResourceType:      "google_redis_instance",
+					Provider:          providers.GCP,
+					Region:            "europe-west1",
+					Count:             3,
+					ReplicationFactor: 1,
+				},
+				Specs: &resources.ComputeResourceSpecs{
+					VCPUs:      4,
+					MemoryMb:   16384,
+					HddStorage: decimal.Zero,
+					SsdStorage: decimal.Zero,
+				},
+			},
+		},
+		{
+			name:    "memorystore basic",
+			mapping: "google_redis_instance",
+			resource: tfjson.StateResource{
+				Address:      "google_redis_instance.sessions",
+				Type:         "google_redis_instance",
+				Name:         "sessions",
+				ProviderName: "registry.terraform.io/hashicorp/google",
+				AttributeValues: map[string]interface{}{
+					"tier":           "BASIC",
+					"memory_size_gb": 1,
+					"location_id":    "europe-west9-a",
+				},
+			},
+			want: resources.ComputeResource{
+				Identification: &resources.ResourceIdentification{
+					Address:           "google_redis_instance.sessions",
+					Name:              "sessions",
+					ResourceType:      "google_redis_instance",
+					Provider:          providers.GCP,
+					Region:            "europe-west9",
+					Count:             1,
+					ReplicationFactor: 1,
+				},
+				Specs: &resources.ComputeResourceSpecs{
+					VCPUs:      1,
+					MemoryMb:   1024,
+					HddStorage: decimal.Zero,
+					SsdStorage: decimal.Zero,
+				},
+			},
+		},
+		{
+			name:    "elasticache cluster mode with data tiering",
+			mapping: "aws_elasticache_replication_group",
+			resource: tfjson.StateResource{
+				Address:      "aws_elasticache_replication_group.cache",
+				Type:         "aws_elasticache_replication_group",
+				Name:         "cache",
+				ProviderName: "registry.terraform.io/hashicorp/aws",
+				AttributeValues: map[string]interface{}{
+					"node_type":               "cache.r6gd.xlarge",
+					"num_node_groups":         3,
+					"replicas_per_node_group": 1,
+					"multi_az_enabled":        true,
+				},
+			},
+			want: resources.ComputeResource{
+				Identification: &resources.ResourceIdentification{
+					Address:           "aws_elasticache_replication_group.cache",
+					Name:              "cache",
+					ResourceType:      "aws_elasticache_replication_group",
+					Provider:          providers.AWS,
+					Region:            "eu-west-3",
+					Count:             6,
+					ReplicationFactor: 1,
+				},
+				Specs: &resources.ComputeResourceSpecs{
+					VCPUs:      4,
+					MemoryMb:   26952,
+					CPUType:    "Graviton2",
+					HddStorage: decimal.Zero,
+					SsdStorage: decimal.NewFromInt(99),
+				},
+			},
+		},
+		{
+			name:    "elasticache cluster mode disabled",
+			mapping: "aws_elasticache_replication_group",
+			resource: tfjson.StateResource{
+				Address:      "aws_elasticache_replication_group.sessions",
+				Type:         "aws_elasticache_replication_group",
+				Name:         "sessions",
+				ProviderName: "registry.terraform.io/hashicorp/aws",
+				AttributeValues: map[string]interface{}{
+					"node_type":                   "cache.t4g.small",
+					"num_cache_clusters":          2,
+					"preferred_cache_cluster_azs": []interface{}{"eu-west-1a", "eu-west-1b"},
+				},
+			},
+			want: resources.ComputeResource{
+				Identification: &resources.ResourceIdentification{
+					Address:           "aws_elasticache_replication_group.sessions",
+					Name:              "sessions",
+					ResourceType:      "aws_elasticache_replication_group",
+					Provider:          providers.AWS,
+					Region:            "eu-west-1",
+					Count:             2,
+					ReplicationFactor: 1,
+				},
+				Specs: &resources.ComputeResourceSpecs{
+					VCPUs:      2,
+					MemoryMb:   1403,
+					CPUType:    "Graviton2",
+					HddStorage: decimal.Zero,
+					SsdStorage: decimal.Zero,
+				},
+			},
+		},
+		{
+			name:    "elasticache memcached cluster",
+			mapping: "aws_elasticache_cluster",
+			resource: tfjson.StateResource{
+				Address:      "aws_elasticache_cluster.memcached",
+				Type:         "aws_elasticache_cluster",
+				Name:         "memcached",
+				ProviderName: "registry.terraform.io/hashicorp/aws",
+				AttributeValues: map[string]interface{}{
+					"engine":          "memcached",
+					"node_type":       "cache.m6g.large",
+					"num_cache_nodes": 3,
+				},
+			},
+			want: resources.ComputeResource{
+				Identification: &resources.ResourceIdentification{
+					Address:           "aws_elasticache_cluster.memcached",
+					Name:              "memcached",
+					ResourceType:      "aws_elasticache_cluster",
+					Provider:          providers.AWS,
+					Region:            "eu-west-3",
+					Count:             3,
+					ReplicationFactor: 1,
+				},
+				Specs: &resources.ComputeResourceSpecs{
+					VCPUs:      2,
+					MemoryMb:   6533,
+					CPUType:    "Graviton2",
+					HddStorage: decimal.Zero,
+					SsdStorage: decimal.Zero,
+				},
+			},
+		},
+		{
+			name:    "opensearch data nodes with ebs",
+			mapping: "aws_opensearch_domain",
+			resource: tfjson.StateResource{
+				Address:      "aws_opensearch_domain.logs",
+				Type:         "aws_opensearch_domain",
+				Name:         "logs",
+				ProviderName: "registry.terraform.io/hashicorp/aws",
+				AttributeValues: map[string]interface{}{
+					"cluster_config": []interface{}{
+						map[string]interface{}{
+							"instance_type":                 "r6g.large.search",
+							"instance_count":                6,
+							"zone_awareness_enabled":        true,
+							"multi_az_with_standby_enabled": true,
+						},
+					},
+					"ebs_options": []interface{}{
+						map[string]interface{}{
+							"ebs_enabled": true,
+							"volume_size": 100,
+							"volume_type": "gp3",
+						},
+					},
+				},
+			},
+			want: resources.ComputeResource{
+				Identification: &resources.ResourceIdentification{
+					Address:           "aws_opensearch_domain.logs",
+					Name:              "logs",
+					ResourceType:      "aws_opensearch_domain",
+					Provider:          providers.AWS,
+					Region:            "eu-west-3",
+					Count:             6,
+					ReplicationFactor: 1,
+				},
+				Specs: &resources.ComputeResourceSpecs{
+					VCPUs:                       2,
+					MemoryMb:                    16384,
+					CPUType:                     "Graviton2",
+					HddStorage:                  decimal.Zero,
+					SsdStorage:                  decimal.NewFromInt(100),
+					SsdStorageReplicationFactor: decimal.NewFromInt(2),
+				},
+			},
+		},
+		{
+			name:    "elasticsearch storage optimized data nodes",
+			mapping: "aws_opensearch_domain",
+			resource: tfjson.StateResource{
+				Address:      "aws_elasticsearch_domain.search",
+				Type:         "aws_elasticsearch_domain",
+				Name:         "search",
+				ProviderName: "registry.terraform.io/hashicorp/aws",
+				AttributeValues: map[string]interface{}{
+					"cluster_config": []interface{}{
+						map[string]interface{}{
+							"instance_type":  "i3.large.elasticsearch",
+							"instance_count": 2,
+						},
+					},
+					"ebs_options": []interface{}{
+						map[string]interface{}{"ebs_enabled": false},
+					},
+				},
+			},
+			want: resources.ComputeResource{
+				Identification: &resources.ResourceIdentification{
+					Address:           "aws_elasticsearch_domain.search",
+					Name:              "search",
+					ResourceType:      "aws_elasticsearch_domain",
+					Provider:          providers.AWS,
+					Region:            "eu-west-3",
+					Count:             2,
+					ReplicationFactor: 1,
+				},
+				Specs: &resources.ComputeResourceSpecs{
+					VCPUs:      2,
+					MemoryMb:   15616,
+					CPUType:    "Broadwell",
+					HddStorage: decimal.Zero,
+					SsdStorage: decimal.NewFromInt(475),
+				},
+			},
+		},
+		{
+			name:    "elasticache data tiering node type not in catalog",
+			mapping: "aws_elasticache_cluster",
+			resource: tfjson.StateResource{
+				Address:      "aws_elasticache_cluster.unknown",
+				Type:         "aws_elasticache_cluster",
+				Name:         "unknown",
+				ProviderName: "registry.terraform.io/hashicorp/aws",
+				AttributeValues: map[string]interface{}{
+					"node_type": "cache.r6gd.24xlarge",
+				},
+			},
+			want: resources.ComputeResource{
+				Identification: &resources.ResourceIdentification{
+					Address:           "aws_elasticache_cluster.unknown",
+					Name:              "unknown",
+					ResourceType:      "aws_elasticache_cluster",
+					Provider:          providers.AWS,
+					Region:            "eu-west-3",
+					Count:             1,
+					ReplicationFactor: 1,
+				},
+				Specs: &resources.ComputeResourceSpecs{
+					HddStorage: decimal.Zero,
+					SsdStorage: decimal.NewFromInt(0),
+				},
+			},
+		},
+		{
+			name:    "opensearch storage optimized instance type not in catalog",
+			mapping: "aws_opensearch_domain",
+			resource: tfjson.StateResource{
+				Address:      "aws_opensearch_domain.unknown",
+				Type:         "aws_opensearch_domain",
+				Name:         "unknown",
+				ProviderName: "registry.terraform.io/hashicorp/aws",
+				AttributeValues: map[string]interface{}{
+					"cluster_config": []interface{}{
+						map[string]interface{}{
+							"instance_type":  "im4gn.24xlarge.search",
+							"instance_count": 3,
+						},
+					},
+				},
+			},
+			want: resources.ComputeResource{
+				Identification: &resources.ResourceIdentification{
+					Address:           "aws_opensearch_domain.unknown",
+					Name:              "unknown",
+					ResourceType:      "aws_opensearch_domain",
+					Provider:          providers.AWS,
+					Region:            "eu-west-3",
+					Count:             3,
+					ReplicationFactor: 1,
+				},
+				Specs: &resources.ComputeResourceSpecs{
+					HddStorage: decimal.Zero,
+					SsdStorage: decimal.NewFromInt(0),
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resource, _ := testutils.TfResourceToJSON(&tt.resource)
+			resourceMapping := (*mapping.ComputeResource)[tt.mapping]
+			got, err := plan.GetComputeResource(*resource, &resourceMapping, nil)
+			assert.NoError(t, err)
+			assert.Len(t, got, 1)
+			assert.Equal(t, tt.want, got[0])
+		})
+	}
+}
+
+func TestGetResources_CacheAndSearchNodes(t *testing.T) {
+	tfPlan := map[string]interface{}{
+		"planned_values": map[string]interface{}{
+			"root_module": map[string]interface{}{
+				"resources": []interface{}{
+					map[string]interface{}{
+						"address":       "aws_opensearch_domain.logs",
+						"type":          "aws_opensearch_domain",
+						"name":          "logs",
+						"provider_name": "registry.terraform.io/hashicorp/aws",
+						"values": map[string]interface{}{
+							"cluster_config": []interface{}{
+								map[string]interface{}{
+									"instance_type":            "r6g.large.search",
+									"instance_count":           3,
+									"dedicated_master_enabled": true,
+									"dedicated_master_type":    "m6g.large.search",
+									"warm_enabled":             true,
+									"warm_type":                "ultrawarm1.medium.search",
+									"warm_count":               2,
+								},
+							},
+						},
+					},
+					map[string]interface{}{
+						"address":       "aws_elasticache_replication_group.cache",
+						"type":          "aws_elasticache_replication_group",
+						"name":          "cache",
+						"provider_name": "registry.terraform.io/hashicorp/aws",
+						"values": map[string]interface{}{
+							"node_type":          "cache.t4g.small",
+							"num_cache_clusters": 2,
+						},
+					},
+					map[string]interface{}{
+						"address":       "aws_elasticache_cluster.replica",
+						"type":          "aws_elasticache_cluster",
+						"name":          "replica",
+						"provider_name": "registry.terraform.io/hashicorp/aws",
+						"values":        map[string]interface{}{},
+					},
+				},
+			},
+		},
+		"configuration": map[string]interface{}{
+			"provider_config": map[string]interface{}{
+				"aws": map[string]interface{}{
+					"expressions": map[string]interface{}{
+						"region": map[string]interface{}{"constant_value": "eu-west-3"},
+					},
+				},
+			},
+			"root_module": map[string]interface{}{
+				"resources": []interface{}{
+					map[string]interface{}{
+						"address": "aws_elasticache_cluster.replica",
+						"expressions": map[string]interface{}{
+							"replication_group_id": map[string]interface{}{
+								"references": []interface{}{"aws_elasticache_replication_group.cache.id", "aws_elasticache_replication_group.cache"},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	defer func() { plan.TfPlan = &map[string]interface{}{} }()
+
+	got, err := plan.GetResources(&tfPlan)
+	assert.NoError(t, err)
+
+	counts := map[string]int64{}
+	for address, resource := range got {
+		if computeResource, ok := resource.(resources.ComputeResource); ok {
+			counts[address] = computeResource.Identification.Count
+		}
+	}
+	assert.Equal(t, map[string]int64{
+		"aws_opensearch_domain.logs":                  3,
+		"aws_opensearch_domain.logs.dedicated_master": 3,
+		"aws_opensearch_domain.logs.warm":             2,
+		"aws_elasticache_replication_group.cache":     2,
+	}, counts)
+	assert.NotContains(t, got, "aws_elasticache_cluster.replica")
+	assert.Equal(t, int32(2), got["aws_opensearch_domain.logs.warm"].(resources.ComputeResource).Specs.VCPUs)
+}
@@ -0,0 +1,270 @@
+package plan_test
+
+import (
+	"testing"
+
+	"github.com/carboniferio/carbonifer/internal/plan"
+	"github.com/carboniferio/carbonifer/internal/providers"
+	"github.com/carboniferio/carbonifer/internal/resources"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetResource_DedicatedHosts(t *testing.T) {
+	mapping, err := plan.GetMapping()
+	assert.NoError(t, err)
+
+	nodeGroup := map[string]interface{}{
+		"address":       "google_compute_node_group.sole",
+		"type":          "google_compute_node_group",
+		"name":          "sole",
+		"provider_name": "registry.terraform.io/hashicorp/google",
+		"values": map[string]interface{}{
+			"name":         "sole-group",
+			"zone":         "europe-west1-b",
+			"initial_size": 2,
+		},
+	}
+	soleTenantVM := map[string]interface{}{
+		"address":       "google_compute_instance.on_sole",
+		"type":          "google_compute_instance",
+		"name":          "on_sole",
+		"provider_name": "registry.terraform.io/hashicorp/google",
+		"values": map[string]interface{}{
+			"machine_type": "n1-standard-2",
+			"zone":         "europe-west1-b",
+			"scheduling": []interface{}{
+				map[string]interface{}{
+					"node_affinities": []interface{}{
+						map[string]interface{}{
+							"key":      "compute.googleapis.com/node-group-name",
+							"operator": "IN",
+							"values":   []interface{}{"sole-group"},
+						},
+					},
+				},
+			},
+		},
+	}
+	host := map[string]interface{}{
+		"address":       "aws_ec2_host.dedicated",
+		"type":          "aws_ec2_host",
+		"name":          "dedicated",
+		"provider_name": "registry.terraform.io/hashicorp/aws",
+		"values": map[string]interface{}{
+			"instance_type":     "m5.large",
+			"availability_zone": "eu-west-3a",
+		},
+	}
+	dedicatedVM := map[string]interface{}{
+		"address":       "aws_instance.on_host",
+		"type":          "aws_instance",
+		"name":          "on_host",
+		"provider_name": "registry.terraform.io/hashicorp/aws",
+		"values": map[string]interface{}{
+			"instance_type":     "m5.xlarge",
+			"availability_zone": "eu-west-3a",
+			"tenancy":           "host",
+		},
+	}
+	plan.TfPlan = &map[string]interface{}{
+		"planned_values": map[string]interface{}{
+			"root_module": map[string]interface{}{
+				"resources": []interface{}{
+					nodeGroup,
+					map[string]interface{}{
+						"address": "google_compute_node_template.tpl",
+						"type":    "google_compute_node_template",
+						"values": map[string]interface{}{
+							"node_type": "n2-node-80-640",
+						},
+					},
+					soleTenantVM,
+					host,
+					dedicatedVM,
+				},
+			},
+		},
+		"configuration": map[string]interface{}{
+			"root_module": map[string]interface{}{
+				"resources": []interface{}{
+					map[string]interface{}{
+						"address": "google_compute_node_group.sole",
+						"type":    "google_compute_node_group",
+						"expressions": map[string]interface{}{
+							"node_template": map[string]interface{}{
+								"references": []interface{}{"google_compute_node_template.tpl.id", "google_compute_node_template.tpl"},
+							},
+						},
+					},
+					// host_id is not known before the host is created
+					map[string]interface{}{
+						"address": "aws_instance.on_host",
+						"type":    "aws_instance",
+						"expressions": map[string]interface{}{
+							"host_id": map[string]interface{}{
+								"references": []interface{}{"aws_ec2_host.dedicated.id", "aws_ec2_host.dedicated"},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	defer func() { plan.TfPlan = &map[string]interface{}{} }()
+
+	tests := []struct {
+		name     string
+		mapping  string
+		resource map[string]interface{}
+		want     resources.ComputeResource
+	}{
+		{
+			name:     "sole-tenant node group",
+			mapping:  "google_compute_node_group",
+			resource: nodeGroup,
+			want: resources.ComputeResource{
+				Identification: &resources.ResourceIdentification{
+					Address:           "google_compute_node_group.sole",
+					Name:              "sole",
+					ResourceType:      "google_compute_node_group",
+					Provider:          providers.GCP,
+					Region:            "europe-west1",
+					Count:             2,
+					ReplicationFactor: 1,
+				},
+				Specs: &resources.ComputeResourceSpecs{
+					VCPUs:      80,
+					MemoryMb:   655360,
+					CPUType:    "Cascade Lake",
+					HddStorage: decimal.Zero,
+					SsdStorage: decimal.Zero,
+				},
+			},
+		},
+		{
+			name:     "vm on sole-tenant node group",
+			mapping:  "google_compute_instance",
+			resource: soleTenantVM,
+			want: resources.ComputeResource{
+				Identification: &resources.ResourceIdentification{
+					Address:           "google_compute_instance.on_sole",
+					Name:              "on_sole",
+					ResourceType:      "google_compute_instance",
+					Provider:          providers.GCP,
+					Region:            "europe-west1",
+					Count:             1,
+					ReplicationFactor: 1,
+				},
+				Specs: &resources.ComputeResourceSpecs{
+					VCPUs:      2,
+					MemoryMb:   7680,
+					HddStorage: decimal.Zero,
+					SsdStorage: decimal.Zero,
+				},
+				DedicatedHost: "google_compute_node_group.sole",
+			},
+		},
+		{
+			name:     "dedicated host of an instance type",
+			mapping:  "aws_ec2_host",
+			resource: host,
+			want: resources.ComputeResource{
+				Identification: &resources.ResourceIdentification{
+					Address:           "aws_ec2_host.dedicated",
+					Name:              "dedicated",
+					ResourceType:      "aws_ec2_host",
+					Provider:          providers.AWS,
+					Region:            "eu-west-3",
+					Count:             1,
+					ReplicationFactor: 1,
+				},
+				Specs: &resources.ComputeResourceSpecs{
+					VCPUs:      96,
+					MemoryMb:   393216,
+					CPUType:    "Skylake",
+					HddStorage: decimal.Zero,
+					SsdStorage: decimal.Zero,
+				},
+			},
+		},
+		{
+			name:     "instance on dedicated host",
+			mapping:  "aws_instance",
+			resource: dedicatedVM,
+			want: resources.ComputeResource{
+				Identification: &resources.ResourceIdentification{
+					Address:           "aws_instance.on_host",
+					Name:              "on_host",
+					ResourceType:      "aws_instance",
+					Provider:          providers.AWS,
+					Region:            "eu-west-3",
+					Count:             1,
+					ReplicationFactor: 1,
+				},
+				Specs: &resources.ComputeResourceSpecs{
+					VCPUs:      4,
+					MemoryMb:   16384,
+					CPUType:    "Skylake",
+					HddStorage: decimal.Zero,
+					SsdStorage: decimal.Zero,
+				},
+				DedicatedHost: "aws_ec2_host.dedicated",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resourceMapping := (*mapping.ComputeResource)[tt.mapping]
+			got, err := plan.GetComputeResource(tt.resource, &resourceMapping, nil)
+			assert.NoError(t, err)
+			assert.Len(t, got, 1)
+			assert.Equal(t, tt.want, got[0])
+		})
+	}
+}
+
+func TestGetResource_DedicatedHostsWithoutPlan(t *testing.T) {
+	mapping, err := plan.GetMapping()
+	assert.NoError(t, err)
+
+	previousPlan := plan.TfPlan
+	plan.TfPlan = nil
+	defer func() { plan.TfPlan = previousPlan }()
+
+	instance := map[string]interface{}{
+		"address":       "aws_instance.on_host",
+		"type":          "aws_instance",
+		"name":          "on_host",
+		"provider_name": "registry.terraform.io/hashicorp/aws",
+		"values": map[string]interface{}{
+			"instance_type":     "m5.xlarge",
+			"availability_zone": "eu-west-3a",
+			"tenancy":           "host",
+		},
+	}
+	want := resources.ComputeResource{
+		Identification: &resources.ResourceIdentification{
+			Address:           "aws_instance.on_host",
+			Name:              "on_host",
+			ResourceType:      "aws_instance",
+			Provider:          providers.AWS,
+			Region:            "eu-west-3",
+			Count:             1,
+			ReplicationFactor: 1,
+		},
+		Specs: &resources.ComputeResourceSpecs{
+			VCPUs:      4,
+			MemoryMb:   16384,
+			CPUType:    "Skylake",
+			HddStorage: decimal.Zero,
+			SsdStorage: decimal.Zero,
+		},
+	}
+
+	resourceMapping := (*mapping.ComputeResource)["aws_instance"]
+	got, err := plan.GetComputeResource(instance, &resourceMapping, nil)
+	assert.NoError(t, err)
+	assert.Len(t, got, 1)
+	assert.Equal(t, want, got[0])
+}
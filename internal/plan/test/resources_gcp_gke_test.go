@@ -9,6 +9,7 @@ import (
 	"github.com/carboniferio/carbonifer/internal/resources"
 	"github.com/carboniferio/carbonifer/internal/terraform"
 	"github.com/carboniferio/carbonifer/internal/testutils"
+	tfjson "github.com/hashicorp/terraform-json"
 	"github.com/shopspring/decimal"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
@@ -160,8 +161,8 @@ func TestGetResource_GCP_GKE(t *testing.T) {
 	assert.NoError(t, err)
 	for _, got := range gotResources {
 		if got.GetIdentification().ResourceType == "google_container_node_pool" {
-			// This should not exists, it should be ignored
-			assert.Fail(t, "google_container_node_pool should be ignored")
+			// This should not exists, it is estimated with its cluster
+			assert.Fail(t, "google_container_node_pool should be estimated with its cluster")
 		} else if got.GetIdentification().ResourceType == "google_container_cluster" {
 			assert.Equal(t, wantResources[got.GetAddress()], got)
 		} else {
@@ -170,3 +171,239 @@ func TestGetResource_GCP_GKE(t *testing.T) {
 		}
 	}
 }
+
+func TestGetResource_GKENodePools(t *testing.T) {
+	nodePool := func(name string, values map[string]interface{}) map[string]interface{} {
+		return map[string]interface{}{
+			"address":       "google_container_node_pool." + name,
+			"type":          "google_container_node_pool",
+			"name":          name,
+			"provider_name": "registry.terraform.io/hashicorp/google",
+			"values":        values,
+		}
+	}
+	nodePoolConfig := func(name string, cluster string) map[string]interface{} {
+		return map[string]interface{}{
+			"address": "google_container_node_pool." + name,
+			"type":    "google_container_node_pool",
+			"expressions": map[string]interface{}{
+				"cluster": map[string]interface{}{
+					"references": []interface{}{cluster + ".id", cluster},
+				},
+			},
+		}
+	}
+	nodeConfig := []interface{}{
+		map[string]interface{}{"machine_type": "n1-standard-2", "disk_size_gb": 50, "disk_type": "pd-ssd"},
+	}
+	tfPlan := &map[string]interface{}{
+		"planned_values": map[string]interface{}{
+			"root_module": map[string]interface{}{
+				"resources": []interface{}{
+					map[string]interface{}{
+						"address":       "google_container_cluster.main",
+						"type":          "google_container_cluster",
+						"name":          "main",
+						"provider_name": "registry.terraform.io/hashicorp/google",
+						"values": map[string]interface{}{
+							"location": "europe-west9-a",
+						},
+					},
+					// Estimated with its cluster
+					nodePool("main_nodes", map[string]interface{}{
+						"location":    "europe-west9-a",
+						"node_count":  2,
+						"node_config": nodeConfig,
+					}),
+					// Second node pool of the cluster
+					nodePool("extra_nodes", map[string]interface{}{
+						"location":    "europe-west9-a",
+						"node_count":  3,
+						"node_config": nodeConfig,
+					}),
+					// Node pool of a cluster created elsewhere
+					nodePool("remote_nodes", map[string]interface{}{
+						"location":       "europe-west9",
+						"node_locations": []interface{}{"europe-west9-a", "europe-west9-b"},
+						"autoscaling": []interface{}{
+							map[string]interface{}{"min_node_count": 1, "max_node_count": 5},
+						},
+						"node_config": nodeConfig,
+					}),
+				},
+			},
+		},
+		"configuration": map[string]interface{}{
+			"root_module": map[string]interface{}{
+				"resources": []interface{}{
+					nodePoolConfig("main_nodes", "google_container_cluster.main"),
+					nodePoolConfig("extra_nodes", "google_container_cluster.main"),
+					nodePoolConfig("remote_nodes", "data.google_container_cluster.remote"),
+				},
+			},
+		},
+	}
+	defer func() { plan.TfPlan = &map[string]interface{}{} }()
+
+	gotResources, err := plan.GetResources(tfPlan)
+	assert.NoError(t, err)
+	assert.NotContains(t, gotResources, "google_container_node_pool.main_nodes")
+	assert.Equal(t, int64(2), gotResources["google_container_cluster.main"].GetIdentification().Count)
+
+	wantResources := map[string]resources.Resource{
+		"google_container_node_pool.extra_nodes": resources.ComputeResource{
+			Identification: &resources.ResourceIdentification{
+				Name:              "extra_nodes",
+				ResourceType:      "google_container_node_pool",
+				Provider:          providers.GCP,
+				Region:            "europe-west9",
+				Count:             3,
+				ReplicationFactor: 1,
+				Address:           "google_container_node_pool.extra_nodes",
+			},
+			Specs: &resources.ComputeResourceSpecs{
+				VCPUs:      int32(2),
+				MemoryMb:   int32(7680),
+				HddStorage: decimal.Zero,
+				SsdStorage: decimal.NewFromInt(50),
+			},
+			Cluster: "google_container_cluster.main",
+		},
+		"google_container_node_pool.remote_nodes": resources.ComputeResource{
+			Identification: &resources.ResourceIdentification{
+				Name:              "remote_nodes",
+				ResourceType:      "google_container_node_pool",
+				Provider:          providers.GCP,
+				Region:            "europe-west9",
+				Count:             3,
+				ReplicationFactor: 2,
+				Address:           "google_container_node_pool.remote_nodes",
+			},
+			Specs: &resources.ComputeResourceSpecs{
+				VCPUs:      int32(2),
+				MemoryMb:   int32(7680),
+				HddStorage: decimal.Zero,
+				SsdStorage: decimal.NewFromInt(50),
+			},
+		},
+	}
+	for address, want := range wantResources {
+		assert.Equal(t, want, gotResources[address], address)
+	}
+}
+
+func TestGetResource_GKEAutopilot(t *testing.T) {
+	mapping, err := plan.GetMapping()
+	assert.NoError(t, err)
+	workload := func(resourceType string, name string, replicas interface{}, containers ...interface{}) map[string]interface{} {
+		return map[string]interface{}{
+			"address":       resourceType + "." + name,
+			"type":          resourceType,
+			"name":          name,
+			"provider_name": "registry.terraform.io/hashicorp/kubernetes",
+			"values": map[string]interface{}{
+				"spec": []interface{}{
+					map[string]interface{}{
+						"replicas": replicas,
+						"template": []interface{}{
+							map[string]interface{}{
+								"spec": []interface{}{
+									map[string]interface{}{"container": containers},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+	container := func(resourcesKind string, cpu string, memory string) map[string]interface{} {
+		return map[string]interface{}{
+			"resources": []interface{}{
+				map[string]interface{}{
+					resourcesKind: map[string]interface{}{"cpu": cpu, "memory": memory},
+				},
+			},
+		}
+	}
+	workloadConfig := func(address string, providerConfigKey string) map[string]interface{} {
+		return map[string]interface{}{
+			"address":             address,
+			"provider_config_key": providerConfigKey,
+		}
+	}
+	plan.TfPlan = &map[string]interface{}{
+		"planned_values": map[string]interface{}{
+			"root_module": map[string]interface{}{
+				"resources": []interface{}{
+					// 3 pods of 0.5 vCPU (by steps of 0.25) and 512 MiB
+					workload("kubernetes_deployment", "web", "3", container("requests", "300m", "512Mi")),
+					// 1 pod of 1.5 vCPUs and 10 GiB, raised to 1.75 vCPUs (6.5 GiB per vCPU at most)
+					workload("kubernetes_stateful_set_v1", "db", "1", map[string]interface{}{}, container("limits", "1", "8Gi")),
+					// On another cluster
+					workload("kubernetes_deployment", "other", "10", container("requests", "1", "1Gi")),
+				},
+			},
+		},
+		"configuration": map[string]interface{}{
+			"provider_config": map[string]interface{}{
+				"kubernetes": map[string]interface{}{
+					"expressions": map[string]interface{}{
+						"host": map[string]interface{}{
+							"references": []interface{}{"google_container_cluster.autopilot.endpoint", "google_container_cluster.autopilot"},
+						},
+					},
+				},
+				"kubernetes.other": map[string]interface{}{
+					"expressions": map[string]interface{}{
+						"host": map[string]interface{}{
+							"references": []interface{}{"google_container_cluster.other.endpoint", "google_container_cluster.other"},
+						},
+					},
+				},
+			},
+			"root_module": map[string]interface{}{
+				"resources": []interface{}{
+					workloadConfig("kubernetes_deployment.web", "kubernetes"),
+					workloadConfig("kubernetes_stateful_set_v1.db", "kubernetes"),
+					workloadConfig("kubernetes_deployment.other", "kubernetes.other"),
+				},
+			},
+		},
+	}
+	defer func() { plan.TfPlan = &map[string]interface{}{} }()
+
+	tests := []struct {
+		name         string
+		address      string
+		wantVCPUs    string
+		wantMemoryMb int32
+	}{
+		{"workloads", "google_container_cluster.autopilot", "3.25", 11776},
+		{"no workloads", "google_container_cluster.empty", "0", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resource, _ := testutils.TfResourceToJSON(&tfjson.StateResource{
+				Address:      tt.address,
+				Type:         "google_container_cluster",
+				Name:         "autopilot",
+				ProviderName: "registry.terraform.io/hashicorp/google",
+				AttributeValues: map[string]interface{}{
+					"location":         "europe-west9",
+					"enable_autopilot": true,
+				},
+			})
+			resourceMapping := (*mapping.ComputeResource)["google_container_cluster_autopilot"]
+			got, err := plan.GetComputeResource(*resource, &resourceMapping, nil)
+			assert.NoError(t, err)
+			assert.Len(t, got, 1)
+			gotResource := got[0].(resources.ComputeResource)
+			assert.Equal(t, "europe-west9", gotResource.Identification.Region)
+			assert.Equal(t, int64(1), gotResource.Identification.Count)
+			assert.True(t, gotResource.PodRequests)
+			assert.Equal(t, tt.wantVCPUs, gotResource.Specs.GetVCPUs().String())
+			assert.Equal(t, tt.wantMemoryMb, gotResource.Specs.MemoryMb)
+		})
+	}
+}
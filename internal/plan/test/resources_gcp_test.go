@@ -157,11 +157,12 @@ func TestGetResource(t *testing.T) {
 					Provider:          providers.GCP,
 					Region:            "europe-west9",
 					Count:             1,
-					ReplicationFactor: 2,
+					ReplicationFactor: 1,
 				},
 				Specs: &resources.ComputeResourceSpecs{
-					HddStorage: decimal.Zero,
-					SsdStorage: decimal.NewFromInt(1024),
+					HddStorage:                  decimal.Zero,
+					SsdStorage:                  decimal.NewFromInt(1024),
+					SsdStorageReplicationFactor: decimal.NewFromInt(2),
 				},
 			},
 		},
@@ -396,3 +397,43 @@ func TestGetResources_InstanceFromTemplate(t *testing.T) {
 	}
 
 }
+
+func TestGetResource_Lifecycle(t *testing.T) {
+	mapping, err := plan.GetMapping()
+	assert.NoError(t, err)
+	plan.TfPlan = &map[string]interface{}{}
+
+	tests := []struct {
+		name       string
+		scheduling interface{}
+		want       string
+	}{
+		{"no scheduling", nil, ""},
+		{"standard", []interface{}{map[string]interface{}{"preemptible": false, "provisioning_model": "STANDARD"}}, ""},
+		{"preemptible", []interface{}{map[string]interface{}{"preemptible": true, "provisioning_model": "STANDARD"}}, resources.LifecyclePreemptible},
+		{"spot", []interface{}{map[string]interface{}{"preemptible": false, "provisioning_model": "SPOT"}}, resources.LifecycleSpot},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tfResource := tfjson.StateResource{
+				Address:      "google_compute_instance.foo",
+				Type:         "google_compute_instance",
+				Name:         "foo",
+				ProviderName: "google",
+				AttributeValues: map[string]interface{}{
+					"name":         "foo",
+					"machine_type": "n1-standard-2",
+					"zone":         "europe-west9-a",
+					"boot_disk":    []interface{}{},
+					"scheduling":   tt.scheduling,
+				},
+			}
+			resource, _ := testutils.TfResourceToJSON(&tfResource)
+			instanceMapping := (*mapping.ComputeResource)["google_compute_instance"]
+			got, err := plan.GetComputeResource(*resource, &instanceMapping, nil)
+			assert.NoError(t, err)
+			assert.Len(t, got, 1)
+			assert.Equal(t, tt.want, got[0].GetIdentification().Lifecycle)
+		})
+	}
+}
@@ -0,0 +1,198 @@
+package plan_test
+
+import (
+	"testing"
+
+	"github.com/carboniferio/carbonifer/internal/plan"
+	"github.com/carboniferio/carbonifer/internal/providers"
+	"github.com/carboniferio/carbonifer/internal/resources"
+	"github.com/carboniferio/carbonifer/internal/testutils"
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetResource_TPU(t *testing.T) {
+	mapping, err := plan.GetMapping()
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		resource tfjson.StateResource
+		want     resources.ComputeResource
+	}{
+		{
+			name: "tpu node v3-8",
+			resource: tfjson.StateResource{
+				Address:      "google_tpu_node.training",
+				Type:         "google_tpu_node",
+				Name:         "training",
+				ProviderName: "registry.terraform.io/hashicorp/google",
+				AttributeValues: map[string]interface{}{
+					"zone":             "europe-west4-a",
+					"accelerator_type": "v3-8",
+					"scheduling_config": []interface{}{
+						map[string]interface{}{"preemptible": true},
+					},
+				},
+			},
+			want: resources.ComputeResource{
+				Identification: &resources.ResourceIdentification{
+					Address:           "google_tpu_node.training",
+					Name:              "training",
+					ResourceType:      "google_tpu_node",
+					Provider:          providers.GCP,
+					Region:            "europe-west4",
+					Count:             4,
+					ReplicationFactor: 1,
+					Lifecycle:         resources.LifecyclePreemptible,
+				},
+				Specs: &resources.ComputeResourceSpecs{
+					VCPUs:      24,
+					MemoryMb:   85760,
+					GpuTypes:   []string{"tpu-v3"},
+					HddStorage: decimal.Zero,
+					SsdStorage: decimal.Zero,
+				},
+			},
+		},
+		{
+			name: "tpu vm v5e slice",
+			resource: tfjson.StateResource{
+				Address:      "google_tpu_v2_vm.serving",
+				Type:         "google_tpu_v2_vm",
+				Name:         "serving",
+				ProviderName: "registry.terraform.io/hashicorp/google",
+				AttributeValues: map[string]interface{}{
+					"zone":             "us-west4-a",
+					"accelerator_type": "v5litepod-4",
+				},
+			},
+			want: resources.ComputeResource{
+				Identification: &resources.ResourceIdentification{
+					Address:           "google_tpu_v2_vm.serving",
+					Name:              "serving",
+					ResourceType:      "google_tpu_v2_vm",
+					Provider:          providers.GCP,
+					Region:            "us-west4",
+					Count:             4,
+					ReplicationFactor: 1,
+				},
+				Specs: &resources.ComputeResourceSpecs{
+					VCPUs:      28,
+					MemoryMb:   48128,
+					GpuTypes:   []string{"tpu-v5litepod"},
+					HddStorage: decimal.Zero,
+					SsdStorage: decimal.Zero,
+				},
+			},
+		},
+		{
+			name: "tpu vm v4 topology",
+			resource: tfjson.StateResource{
+				Address:      "google_tpu_v2_vm.pod",
+				Type:         "google_tpu_v2_vm",
+				Name:         "pod",
+				ProviderName: "registry.terraform.io/hashicorp/google",
+				AttributeValues: map[string]interface{}{
+					"zone": "us-central2-b",
+					"accelerator_config": []interface{}{
+						map[string]interface{}{"type": "V4", "topology": "2x2x4"},
+					},
+					"scheduling_config": []interface{}{
+						map[string]interface{}{"spot": true},
+					},
+				},
+			},
+			want: resources.ComputeResource{
+				Identification: &resources.ResourceIdentification{
+					Address:           "google_tpu_v2_vm.pod",
+					Name:              "pod",
+					ResourceType:      "google_tpu_v2_vm",
+					Provider:          providers.GCP,
+					Region:            "us-central2",
+					Count:             16,
+					ReplicationFactor: 1,
+					Lifecycle:         resources.LifecycleSpot,
+				},
+				Specs: &resources.ComputeResourceSpecs{
+					VCPUs:      60,
+					MemoryMb:   104192,
+					GpuTypes:   []string{"tpu-v4"},
+					HddStorage: decimal.Zero,
+					SsdStorage: decimal.Zero,
+				},
+			},
+		},
+		{
+			name: "tpu vm v5p-8",
+			resource: tfjson.StateResource{
+				Address:      "google_tpu_v2_vm.v5p",
+				Type:         "google_tpu_v2_vm",
+				Name:         "v5p",
+				ProviderName: "registry.terraform.io/hashicorp/google",
+				AttributeValues: map[string]interface{}{
+					"zone":             "us-east5-a",
+					"accelerator_type": "v5p-8",
+				},
+			},
+			want: resources.ComputeResource{
+				Identification: &resources.ResourceIdentification{
+					Address:           "google_tpu_v2_vm.v5p",
+					Name:              "v5p",
+					ResourceType:      "google_tpu_v2_vm",
+					Provider:          providers.GCP,
+					Region:            "us-east5",
+					Count:             4,
+					ReplicationFactor: 1,
+				},
+				Specs: &resources.ComputeResourceSpecs{
+					VCPUs:      52,
+					MemoryMb:   114688,
+					GpuTypes:   []string{"tpu-v5p"},
+					HddStorage: decimal.Zero,
+					SsdStorage: decimal.Zero,
+				},
+			},
+		},
+		{
+			name: "tpu vm of unknown generation",
+			resource: tfjson.StateResource{
+				Address:      "google_tpu_v2_vm.next",
+				Type:         "google_tpu_v2_vm",
+				Name:         "next",
+				ProviderName: "registry.terraform.io/hashicorp/google",
+				AttributeValues: map[string]interface{}{
+					"zone":             "us-central1-a",
+					"accelerator_type": "v9-8",
+				},
+			},
+			want: resources.ComputeResource{
+				Identification: &resources.ResourceIdentification{
+					Address:           "google_tpu_v2_vm.next",
+					Name:              "next",
+					ResourceType:      "google_tpu_v2_vm",
+					Provider:          providers.GCP,
+					Region:            "us-central1",
+					Count:             8,
+					ReplicationFactor: 1,
+				},
+				Specs: &resources.ComputeResourceSpecs{
+					GpuTypes:   []string{"tpu-v9"},
+					HddStorage: decimal.Zero,
+					SsdStorage: decimal.Zero,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resource, _ := testutils.TfResourceToJSON(&tt.resource)
+			resourceMapping := (*mapping.ComputeResource)["google_tpu"]
+			got, err := plan.GetComputeResource(*resource, &resourceMapping, nil)
+			assert.NoError(t, err)
+			assert.Len(t, got, 1)
+			assert.Equal(t, tt.want, got[0])
+		})
+	}
+}
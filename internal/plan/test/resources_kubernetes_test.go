@@ -0,0 +1,179 @@
+package plan_test
+
+import (
+	"testing"
+
+	"github.com/carboniferio/carbonifer/internal/plan"
+	"github.com/carboniferio/carbonifer/internal/providers"
+	"github.com/carboniferio/carbonifer/internal/resources"
+	"github.com/carboniferio/carbonifer/internal/testutils"
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetResource_KubernetesWorkloads(t *testing.T) {
+	mapping, err := plan.GetMapping()
+	assert.NoError(t, err)
+	plan.TfPlan = &map[string]interface{}{
+		"planned_values": map[string]interface{}{
+			"root_module": map[string]interface{}{
+				"resources": []interface{}{
+					map[string]interface{}{
+						"address": "google_container_cluster.main",
+						"type":    "google_container_cluster",
+						"values": map[string]interface{}{
+							"location": "europe-west9-a",
+						},
+					},
+				},
+			},
+		},
+		"configuration": map[string]interface{}{
+			"provider_config": map[string]interface{}{
+				"kubernetes": map[string]interface{}{
+					"expressions": map[string]interface{}{
+						"host": map[string]interface{}{
+							"references": []interface{}{"google_container_cluster.main.endpoint", "google_container_cluster.main"},
+						},
+					},
+				},
+				"kubernetes.remote": map[string]interface{}{
+					"expressions": map[string]interface{}{
+						"host": map[string]interface{}{
+							"references": []interface{}{"data.google_container_cluster.remote.endpoint", "data.google_container_cluster.remote"},
+						},
+					},
+				},
+			},
+			"root_module": map[string]interface{}{
+				"resources": []interface{}{
+					map[string]interface{}{"address": "kubernetes_deployment.web", "provider_config_key": "kubernetes"},
+					map[string]interface{}{"address": "kubernetes_daemonset.agent", "provider_config_key": "kubernetes"},
+					map[string]interface{}{"address": "kubernetes_deployment.remote", "provider_config_key": "kubernetes.remote"},
+				},
+			},
+		},
+	}
+	defer func() { plan.TfPlan = &map[string]interface{}{} }()
+
+	podSpec := func(containers ...interface{}) []interface{} {
+		return []interface{}{
+			map[string]interface{}{
+				"spec": []interface{}{
+					map[string]interface{}{"container": containers},
+				},
+			},
+		}
+	}
+	container := func(resourcesKind string, cpu string, memory string) map[string]interface{} {
+		return map[string]interface{}{
+			"resources": []interface{}{
+				map[string]interface{}{
+					resourcesKind: map[string]interface{}{"cpu": cpu, "memory": memory},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name       string
+		mappingKey string
+		resource   tfjson.StateResource
+		want       []resources.Resource
+	}{
+		{
+			name:       "deployment",
+			mappingKey: "kubernetes_workload",
+			resource: tfjson.StateResource{
+				Address:      "kubernetes_deployment.web",
+				Type:         "kubernetes_deployment",
+				Name:         "web",
+				ProviderName: "registry.terraform.io/hashicorp/kubernetes",
+				AttributeValues: map[string]interface{}{
+					"metadata": []interface{}{map[string]interface{}{"namespace": "shop"}},
+					"spec": []interface{}{
+						map[string]interface{}{
+							"replicas": "3",
+							// Limits are the requests if not set
+							"template": podSpec(container("requests", "250m", "512Mi"), container("limits", "1", "1Gi")),
+						},
+					},
+				},
+			},
+			want: []resources.Resource{
+				resources.WorkloadResource{
+					Identification: &resources.ResourceIdentification{
+						Name:              "web",
+						ResourceType:      "kubernetes_deployment",
+						Provider:          providers.GCP,
+						Region:            "europe-west9",
+						Count:             3,
+						ReplicationFactor: 1,
+						Address:           "kubernetes_deployment.web",
+					},
+					HostAddress: "google_container_cluster.main",
+					Namespace:   "shop",
+					VCPUs:       decimal.RequireFromString("1.25"),
+					MemoryMb:    decimal.NewFromInt(1536),
+				},
+			},
+		},
+		{
+			name:       "daemon set",
+			mappingKey: "kubernetes_daemonset",
+			resource: tfjson.StateResource{
+				Address:      "kubernetes_daemonset.agent",
+				Type:         "kubernetes_daemonset",
+				Name:         "agent",
+				ProviderName: "registry.terraform.io/hashicorp/kubernetes",
+				AttributeValues: map[string]interface{}{
+					"spec": []interface{}{
+						map[string]interface{}{
+							"template": podSpec(container("requests", "100m", "128Mi")),
+						},
+					},
+				},
+			},
+			want: []resources.Resource{
+				resources.WorkloadResource{
+					Identification: &resources.ResourceIdentification{
+						Name:              "agent",
+						ResourceType:      "kubernetes_daemonset",
+						Provider:          providers.GCP,
+						Region:            "europe-west9",
+						Count:             1,
+						ReplicationFactor: 1,
+						Address:           "kubernetes_daemonset.agent",
+					},
+					HostAddress: "google_container_cluster.main",
+					Namespace:   "default",
+					VCPUs:       decimal.RequireFromString("0.1"),
+					MemoryMb:    decimal.NewFromInt(128),
+					PerNode:     true,
+				},
+			},
+		},
+		{
+			name:       "cluster not in plan",
+			mappingKey: "kubernetes_workload",
+			resource: tfjson.StateResource{
+				Address:         "kubernetes_deployment.remote",
+				Type:            "kubernetes_deployment",
+				Name:            "remote",
+				ProviderName:    "registry.terraform.io/hashicorp/kubernetes",
+				AttributeValues: map[string]interface{}{},
+			},
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resource, _ := testutils.TfResourceToJSON(&tt.resource)
+			resourceMapping := (*mapping.ComputeResource)[tt.mappingKey]
+			got, err := plan.GetWorkloadResource(*resource, &resourceMapping, nil)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
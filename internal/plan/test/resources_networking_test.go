@@ -0,0 +1,190 @@
+package plan_test
+
+import (
+	"testing"
+
+	"github.com/carboniferio/carbonifer/internal/plan"
+	"github.com/carboniferio/carbonifer/internal/providers"
+	"github.com/carboniferio/carbonifer/internal/resources"
+	"github.com/carboniferio/carbonifer/internal/testutils"
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetResource_NetworkAppliances(t *testing.T) {
+	mapping, err := plan.GetMapping()
+	assert.NoError(t, err)
+	plan.TfPlan = &map[string]interface{}{
+		"configuration": map[string]interface{}{
+			"provider_config": map[string]interface{}{
+				"aws": map[string]interface{}{
+					"expressions": map[string]interface{}{
+						"region": map[string]interface{}{"constant_value": "eu-west-3"},
+					},
+				},
+				"google": map[string]interface{}{
+					"expressions": map[string]interface{}{
+						"region": map[string]interface{}{"constant_value": "europe-west9"},
+					},
+				},
+			},
+		},
+	}
+	defer func() { plan.TfPlan = &map[string]interface{}{} }()
+
+	tests := []struct {
+		name     string
+		mapping  string
+		resource tfjson.StateResource
+		want     resources.ComputeResource
+	}{
+		{
+			name:    "aws nat gateway",
+			mapping: "aws_network_appliance",
+			resource: tfjson.StateResource{
+				Address:         "aws_nat_gateway.main",
+				Type:            "aws_nat_gateway",
+				Name:            "main",
+				ProviderName:    "registry.terraform.io/hashicorp/aws",
+				AttributeValues: map[string]interface{}{"connectivity_type": "public"},
+			},
+			want: resources.ComputeResource{
+				Identification: &resources.ResourceIdentification{
+					Address:           "aws_nat_gateway.main",
+					Name:              "main",
+					ResourceType:      "aws_nat_gateway",
+					Provider:          providers.AWS,
+					Region:            "eu-west-3",
+					Count:             1,
+					ReplicationFactor: 1,
+				},
+				Specs: &resources.ComputeResourceSpecs{
+					HddStorage: decimal.Zero,
+					SsdStorage: decimal.Zero,
+				},
+				FixedPower: &resources.FixedPower{
+					Watts:         decimal.New(20, 0),
+					WattsPerUnit:  decimal.New(0, 0),
+					CapacityUnits: decimal.Zero,
+				},
+			},
+		},
+		{
+			name:    "aws alb with reserved capacity",
+			mapping: "aws_network_appliance",
+			resource: tfjson.StateResource{
+				Address:      "aws_alb.front",
+				Type:         "aws_alb",
+				Name:         "front",
+				ProviderName: "registry.terraform.io/hashicorp/aws",
+				AttributeValues: map[string]interface{}{
+					"load_balancer_type": "application",
+					"minimum_load_balancer_capacity": []interface{}{
+						map[string]interface{}{"capacity_units": 100},
+					},
+				},
+			},
+			want: resources.ComputeResource{
+				Identification: &resources.ResourceIdentification{
+					Address:           "aws_alb.front",
+					Name:              "front",
+					ResourceType:      "aws_alb",
+					Provider:          providers.AWS,
+					Region:            "eu-west-3",
+					Count:             1,
+					ReplicationFactor: 1,
+				},
+				Specs: &resources.ComputeResourceSpecs{
+					HddStorage: decimal.Zero,
+					SsdStorage: decimal.Zero,
+				},
+				FixedPower: &resources.FixedPower{
+					Watts:         decimal.New(25, 0),
+					WattsPerUnit:  decimal.New(1, 0),
+					CapacityUnits: decimal.New(100, 0),
+				},
+			},
+		},
+		{
+			name:    "gcp global forwarding rule",
+			mapping: "google_network_appliance",
+			resource: tfjson.StateResource{
+				Address:         "google_compute_global_forwarding_rule.https",
+				Type:            "google_compute_global_forwarding_rule",
+				Name:            "https",
+				ProviderName:    "registry.terraform.io/hashicorp/google",
+				AttributeValues: map[string]interface{}{"port_range": "443"},
+			},
+			want: resources.ComputeResource{
+				Identification: &resources.ResourceIdentification{
+					Address:           "google_compute_global_forwarding_rule.https",
+					Name:              "https",
+					ResourceType:      "google_compute_global_forwarding_rule",
+					Provider:          providers.GCP,
+					Region:            "europe-west9",
+					Count:             1,
+					ReplicationFactor: 1,
+				},
+				Specs: &resources.ComputeResourceSpecs{
+					HddStorage: decimal.Zero,
+					SsdStorage: decimal.Zero,
+				},
+				FixedPower: &resources.FixedPower{
+					Watts:         decimal.New(10, 0),
+					WattsPerUnit:  decimal.New(0, 0),
+					CapacityUnits: decimal.Zero,
+				},
+			},
+		},
+		{
+			name:    "azure autoscaled application gateway",
+			mapping: "azurerm_network_appliance",
+			resource: tfjson.StateResource{
+				Address:      "azurerm_application_gateway.front",
+				Type:         "azurerm_application_gateway",
+				Name:         "front",
+				ProviderName: "registry.terraform.io/hashicorp/azurerm",
+				AttributeValues: map[string]interface{}{
+					"location": "West Europe",
+					"sku": []interface{}{
+						map[string]interface{}{"name": "Standard_v2", "tier": "Standard_v2"},
+					},
+					"autoscale_configuration": []interface{}{
+						map[string]interface{}{"min_capacity": 2, "max_capacity": 10},
+					},
+				},
+			},
+			want: resources.ComputeResource{
+				Identification: &resources.ResourceIdentification{
+					Address:           "azurerm_application_gateway.front",
+					Name:              "front",
+					ResourceType:      "azurerm_application_gateway",
+					Provider:          providers.AZURE,
+					Region:            "westeurope",
+					Count:             1,
+					ReplicationFactor: 1,
+				},
+				Specs: &resources.ComputeResourceSpecs{
+					HddStorage: decimal.Zero,
+					SsdStorage: decimal.Zero,
+				},
+				FixedPower: &resources.FixedPower{
+					Watts:         decimal.New(10, 0),
+					WattsPerUnit:  decimal.New(15, 0),
+					CapacityUnits: decimal.New(6, 0),
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resource, _ := testutils.TfResourceToJSON(&tt.resource)
+			resourceMapping := (*mapping.ComputeResource)[tt.mapping]
+			got, err := plan.GetComputeResource(*resource, &resourceMapping, nil)
+			assert.NoError(t, err)
+			assert.Len(t, got, 1)
+			assert.Equal(t, tt.want, got[0])
+		})
+	}
+}
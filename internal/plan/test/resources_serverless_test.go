@@ -0,0 +1,173 @@
+package plan_test
+
+import (
+	"testing"
+
+	"github.com/carboniferio/carbonifer/internal/plan"
+	"github.com/carboniferio/carbonifer/internal/resources"
+	"github.com/carboniferio/carbonifer/internal/testutils"
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetResource_Serverless(t *testing.T) {
+	mapping, err := plan.GetMapping()
+	assert.NoError(t, err)
+	plan.TfPlan = &map[string]interface{}{
+		"planned_values": map[string]interface{}{
+			"root_module": map[string]interface{}{
+				"resources": []interface{}{
+					map[string]interface{}{
+						"address": "aws_lambda_provisioned_concurrency_config.api",
+						"type":    "aws_lambda_provisioned_concurrency_config",
+						"values": map[string]interface{}{
+							"provisioned_concurrent_executions": 2,
+						},
+					},
+				},
+			},
+		},
+		"configuration": map[string]interface{}{
+			"provider_config": map[string]interface{}{
+				"aws": map[string]interface{}{
+					"expressions": map[string]interface{}{
+						"region": map[string]interface{}{"constant_value": "eu-west-3"},
+					},
+				},
+			},
+			"root_module": map[string]interface{}{
+				"resources": []interface{}{
+					map[string]interface{}{
+						"address": "aws_lambda_provisioned_concurrency_config.api",
+						"type":    "aws_lambda_provisioned_concurrency_config",
+						"expressions": map[string]interface{}{
+							"function_name": map[string]interface{}{
+								"references": []interface{}{"aws_lambda_function.api.function_name", "aws_lambda_function.api"},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	defer func() { plan.TfPlan = &map[string]interface{}{} }()
+
+	tests := []struct {
+		name           string
+		resource       tfjson.StateResource
+		wantRegion     string
+		wantVCPUs      decimal.Decimal
+		wantMemoryMb   int32
+		wantCPUType    string
+		wantServerless *resources.Serverless
+	}{
+		{
+			name: "lambda",
+			resource: tfjson.StateResource{
+				Address:      "aws_lambda_function.api",
+				Type:         "aws_lambda_function",
+				Name:         "api",
+				ProviderName: "registry.terraform.io/hashicorp/aws",
+				AttributeValues: map[string]interface{}{
+					"memory_size":   1769,
+					"architectures": []interface{}{"arm64"},
+				},
+			},
+			wantRegion:     "eu-west-3",
+			wantVCPUs:      decimal.NewFromInt(1),
+			wantMemoryMb:   1769,
+			wantCPUType:    "Graviton2",
+			wantServerless: &resources.Serverless{MinInstances: 2, Concurrency: 1},
+		},
+		{
+			name: "lambda default",
+			resource: tfjson.StateResource{
+				Address:         "aws_lambda_function.default",
+				Type:            "aws_lambda_function",
+				Name:            "default",
+				ProviderName:    "registry.terraform.io/hashicorp/aws",
+				AttributeValues: map[string]interface{}{},
+			},
+			wantRegion:     "eu-west-3",
+			wantVCPUs:      decimal.NewFromInt(128).Div(decimal.NewFromInt(1769)),
+			wantMemoryMb:   128,
+			wantServerless: &resources.Serverless{MinInstances: 0, Concurrency: 1},
+		},
+		{
+			name: "cloud function",
+			resource: tfjson.StateResource{
+				Address:      "google_cloudfunctions2_function.api",
+				Type:         "google_cloudfunctions2_function",
+				Name:         "api",
+				ProviderName: "registry.terraform.io/hashicorp/google",
+				AttributeValues: map[string]interface{}{
+					"location": "europe-west9",
+					"service_config": []interface{}{
+						map[string]interface{}{
+							"available_memory":                 "1Gi",
+							"min_instance_count":               1,
+							"max_instance_request_concurrency": 10,
+						},
+					},
+				},
+			},
+			wantRegion:     "europe-west9",
+			wantVCPUs:      decimal.RequireFromString("0.583"),
+			wantMemoryMb:   1024,
+			wantServerless: &resources.Serverless{MinInstances: 1, Concurrency: 10},
+		},
+		{
+			name: "cloud run",
+			resource: tfjson.StateResource{
+				Address:      "google_cloud_run_v2_service.api",
+				Type:         "google_cloud_run_v2_service",
+				Name:         "api",
+				ProviderName: "registry.terraform.io/hashicorp/google",
+				AttributeValues: map[string]interface{}{
+					"location": "europe-west9",
+					"template": []interface{}{
+						map[string]interface{}{
+							"containers": []interface{}{
+								map[string]interface{}{
+									"resources": []interface{}{
+										map[string]interface{}{
+											"limits": map[string]interface{}{"cpu": "2", "memory": "2Gi"},
+										},
+									},
+								},
+								map[string]interface{}{
+									"resources": []interface{}{
+										map[string]interface{}{
+											"limits": map[string]interface{}{"cpu": "500m", "memory": "256Mi"},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			wantRegion:     "europe-west9",
+			wantVCPUs:      decimal.RequireFromString("2.5"),
+			wantMemoryMb:   2304,
+			wantServerless: &resources.Serverless{MinInstances: 0, Concurrency: 80},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resource, _ := testutils.TfResourceToJSON(&tt.resource)
+			resourceMapping := (*mapping.ComputeResource)[tt.resource.Type]
+			got, err := plan.GetComputeResource(*resource, &resourceMapping, nil)
+			assert.NoError(t, err)
+			assert.Len(t, got, 1)
+			gotResource := got[0].(resources.ComputeResource)
+			assert.Equal(t, tt.wantRegion, gotResource.Identification.Region)
+			assert.Equal(t, int64(1), gotResource.Identification.Count)
+			assert.Equal(t, tt.wantVCPUs.Round(6).String(), gotResource.Specs.GetVCPUs().Round(6).String())
+			assert.Equal(t, tt.wantMemoryMb, gotResource.Specs.MemoryMb)
+			assert.Equal(t, tt.wantCPUType, gotResource.Specs.CPUType)
+			assert.Equal(t, tt.wantServerless, gotResource.Serverless)
+		})
+	}
+}
@@ -0,0 +1,215 @@
+package plan_test
+
+import (
+	"testing"
+
+	"github.com/carboniferio/carbonifer/internal/plan"
+	"github.com/carboniferio/carbonifer/internal/providers"
+	"github.com/carboniferio/carbonifer/internal/resources"
+	"github.com/carboniferio/carbonifer/internal/testutils"
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetResource_Buckets(t *testing.T) {
+	mapping, err := plan.GetMapping()
+	assert.NoError(t, err)
+	plan.TfPlan = &map[string]interface{}{
+		"planned_values": map[string]interface{}{
+			"root_module": map[string]interface{}{
+				"resources": []interface{}{
+					map[string]interface{}{
+						"address": "aws_s3_bucket_lifecycle_configuration.archive",
+						"type":    "aws_s3_bucket_lifecycle_configuration",
+						"values": map[string]interface{}{
+							"rule": []interface{}{
+								map[string]interface{}{
+									"status": "Enabled",
+									"transition": []interface{}{
+										map[string]interface{}{"days": 90, "storage_class": "ONEZONE_IA"},
+										map[string]interface{}{"days": 30, "storage_class": "STANDARD_IA"},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		"configuration": map[string]interface{}{
+			"provider_config": map[string]interface{}{
+				"aws": map[string]interface{}{
+					"expressions": map[string]interface{}{
+						"region": map[string]interface{}{"constant_value": "eu-west-3"},
+					},
+				},
+			},
+			"root_module": map[string]interface{}{
+				"resources": []interface{}{
+					map[string]interface{}{
+						"address": "aws_s3_bucket_lifecycle_configuration.archive",
+						"type":    "aws_s3_bucket_lifecycle_configuration",
+						"expressions": map[string]interface{}{
+							"bucket": map[string]interface{}{
+								"references": []interface{}{"aws_s3_bucket.archive.id", "aws_s3_bucket.archive"},
+							},
+						},
+					},
+					map[string]interface{}{
+						"address": "aws_s3_bucket_replication_configuration.data",
+						"type":    "aws_s3_bucket_replication_configuration",
+						"expressions": map[string]interface{}{
+							"bucket": map[string]interface{}{
+								"references": []interface{}{"aws_s3_bucket.data.id", "aws_s3_bucket.data"},
+							},
+							"rule": []interface{}{
+								map[string]interface{}{
+									"destination": []interface{}{
+										map[string]interface{}{
+											"bucket": map[string]interface{}{
+												"references": []interface{}{"aws_s3_bucket.replica.arn", "aws_s3_bucket.replica"},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	defer func() { plan.TfPlan = &map[string]interface{}{} }()
+
+	tests := []struct {
+		name     string
+		resource tfjson.StateResource
+		want     resources.ComputeResource
+	}{
+		{
+			name: "s3 replicated",
+			resource: tfjson.StateResource{
+				Address:      "aws_s3_bucket.data",
+				Type:         "aws_s3_bucket",
+				Name:         "data",
+				ProviderName: "registry.terraform.io/hashicorp/aws",
+				AttributeValues: map[string]interface{}{
+					"tags": map[string]interface{}{"carbonifer_stored_gb": "500"},
+				},
+			},
+			want: resources.ComputeResource{
+				Identification: &resources.ResourceIdentification{
+					Address:           "aws_s3_bucket.data",
+					Name:              "data",
+					ResourceType:      "aws_s3_bucket",
+					Provider:          providers.AWS,
+					Region:            "eu-west-3",
+					Count:             1,
+					ReplicationFactor: 2,
+				},
+				Specs: &resources.ComputeResourceSpecs{
+					HddStorage:                  decimal.NewFromInt(500),
+					SsdStorage:                  decimal.Zero,
+					HddStorageReplicationFactor: decimal.NewFromInt(3),
+				},
+				ObjectStorage: true,
+			},
+		},
+		{
+			name: "s3 lifecycle",
+			resource: tfjson.StateResource{
+				Address:         "aws_s3_bucket.archive",
+				Type:            "aws_s3_bucket",
+				Name:            "archive",
+				ProviderName:    "registry.terraform.io/hashicorp/aws",
+				AttributeValues: map[string]interface{}{},
+			},
+			want: resources.ComputeResource{
+				Identification: &resources.ResourceIdentification{
+					Address:           "aws_s3_bucket.archive",
+					Name:              "archive",
+					ResourceType:      "aws_s3_bucket",
+					Provider:          providers.AWS,
+					Region:            "eu-west-3",
+					Count:             1,
+					ReplicationFactor: 1,
+				},
+				Specs: &resources.ComputeResourceSpecs{
+					HddStorage: decimal.NewFromInt(0),
+					SsdStorage: decimal.Zero,
+				},
+				ObjectStorage: true,
+			},
+		},
+		{
+			name: "gcs multi-region",
+			resource: tfjson.StateResource{
+				Address:      "google_storage_bucket.data",
+				Type:         "google_storage_bucket",
+				Name:         "data",
+				ProviderName: "registry.terraform.io/hashicorp/google",
+				AttributeValues: map[string]interface{}{
+					"location": "EU",
+					"labels":   map[string]interface{}{"carbonifer_stored_gb": "2048"},
+				},
+			},
+			want: resources.ComputeResource{
+				Identification: &resources.ResourceIdentification{
+					Address:           "google_storage_bucket.data",
+					Name:              "data",
+					ResourceType:      "google_storage_bucket",
+					Provider:          providers.GCP,
+					Region:            "europe-west1",
+					Count:             1,
+					ReplicationFactor: 1,
+				},
+				Specs: &resources.ComputeResourceSpecs{
+					HddStorage:                  decimal.NewFromInt(2048),
+					SsdStorage:                  decimal.Zero,
+					HddStorageReplicationFactor: decimal.NewFromInt(4),
+				},
+				ObjectStorage: true,
+			},
+		},
+		{
+			name: "gcs region",
+			resource: tfjson.StateResource{
+				Address:      "google_storage_bucket.logs",
+				Type:         "google_storage_bucket",
+				Name:         "logs",
+				ProviderName: "registry.terraform.io/hashicorp/google",
+				AttributeValues: map[string]interface{}{
+					"location": "EUROPE-WEST9",
+				},
+			},
+			want: resources.ComputeResource{
+				Identification: &resources.ResourceIdentification{
+					Address:           "google_storage_bucket.logs",
+					Name:              "logs",
+					ResourceType:      "google_storage_bucket",
+					Provider:          providers.GCP,
+					Region:            "europe-west9",
+					Count:             1,
+					ReplicationFactor: 1,
+				},
+				Specs: &resources.ComputeResourceSpecs{
+					HddStorage:                  decimal.NewFromInt(0),
+					SsdStorage:                  decimal.Zero,
+					HddStorageReplicationFactor: decimal.NewFromInt(2),
+				},
+				ObjectStorage: true,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resource, _ := testutils.TfResourceToJSON(&tt.resource)
+			resourceMapping := (*mapping.ComputeResource)[tt.resource.Type]
+			got, err := plan.GetComputeResource(*resource, &resourceMapping, nil)
+			assert.NoError(t, err)
+			assert.Len(t, got, 1)
+			assert.Equal(t, tt.want, got[0])
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package plan
+
+import (
+	"fmt"
+
+	"github.com/carboniferio/carbonifer/internal/resources"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	log "github.com/sirupsen/logrus"
+)
+
+// GetWorkloadResource returns the workload resource of a resource of the plan, running on a host resource
+func GetWorkloadResource(resourceI interface{}, resourceMapping *ResourceMapping, resourcesResult []resources.Resource) ([]resources.Resource, error) {
+	context, identification, err := getResourceIdentification(resourceI, resourceMapping)
+	if err != nil {
+		return nil, err
+	}
+	if identification == nil {
+		return nil, nil
+	}
+	resourceAddress := identification.Address
+	identification.ReplicationFactor = 1
+
+	workloadResource := resources.WorkloadResource{
+		Identification: identification,
+		VCPUs:          decimal.Zero,
+		MemoryMb:       decimal.Zero,
+	}
+
+	host, err := getString("host", context)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Cannot get host for %v", resourceAddress)
+	}
+	if host != nil {
+		workloadResource.HostAddress = *host
+	} else {
+		log.Warnf("Cannot find the host of %v", resourceAddress)
+	}
+
+	namespace, err := getString("namespace", context)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Cannot get namespace for %v", resourceAddress)
+	}
+	if namespace != nil {
+		workloadResource.Namespace = *namespace
+	}
+
+	vcpus, err := getValue("vCPUs", context)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Cannot get vCPUs for %v", resourceAddress)
+	}
+	if vcpus != nil && vcpus.Value != nil {
+		workloadResource.VCPUs, err = decimal.NewFromString(fmt.Sprintf("%v", vcpus.Value))
+		if err != nil {
+			return nil, errors.Wrapf(err, "Cannot parse vCPUs for %v", resourceAddress)
+		}
+	}
+
+	memory, err := getValue("memory", context)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Cannot get memory for %v", resourceAddress)
+	}
+	if memory != nil && memory.Value != nil {
+		memoryMb, err := getMemoryMb(memory)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Cannot parse memory for %v", resourceAddress)
+		}
+		workloadResource.MemoryMb = decimal.NewFromInt32(memoryMb)
+	}
+
+	count, err := getCount(context)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Cannot get count for %v", resourceAddress)
+	}
+	workloadResource.Identification.Count = count
+
+	perNode, err := getValue("per_node", context)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Cannot get per node for %v", resourceAddress)
+	}
+	workloadResource.PerNode = perNode != nil && fmt.Sprintf("%v", perNode.Value) == "true"
+
+	resourcesResult = append(resourcesResult, workloadResource)
+	log.Debugf("    Reading workload '%s' on '%s'", workloadResource.GetAddress(), workloadResource.HostAddress)
+	return resourcesResult, nil
+}
@@ -0,0 +1,98 @@
+package providers
+
+import (
+	"strings"
+
+	"github.com/carboniferio/carbonifer/internal/data"
+	"github.com/shopspring/decimal"
+	log "github.com/sirupsen/logrus"
+	"github.com/yunabe/easycsv"
+)
+
+var cpuPowerCurves map[string]CPUPowerCurve
+
+// CPUPowerCurve is the power of a vCPU measured at 0%, 10%, ..., 100% load (SPECpower style)
+type CPUPowerCurve struct {
+	Provider     Provider
+	Architecture string
+	Watts        []decimal.Decimal
+}
+
+type cpuPowerCurveCSV struct {
+	Provider     string  `name:"provider"`
+	Architecture string  `name:"architecture"`
+	Load0        float64 `name:"0%"`
+	Load10       float64 `name:"10%"`
+	Load20       float64 `name:"20%"`
+	Load30       float64 `name:"30%"`
+	Load40       float64 `name:"40%"`
+	Load50       float64 `name:"50%"`
+	Load60       float64 `name:"60%"`
+	Load70       float64 `name:"70%"`
+	Load80       float64 `name:"80%"`
+	Load90       float64 `name:"90%"`
+	Load100      float64 `name:"100%"`
+}
+
+// GetCPUPowerCurve returns the power curve of a CPU architecture, or nil if there is none.
+// An empty architecture returns the default curve of the provider.
+func GetCPUPowerCurve(provider Provider, architecture string) *CPUPowerCurve {
+	log.Debugf("  Getting power curve for CPU type: '%v' of %v", architecture, provider)
+	if cpuPowerCurves == nil {
+		// Read the CSV records
+		var records []cpuPowerCurveCSV
+		fileContents := data.ReadDataFile("cpu_power_curves.csv")
+		if err := easycsv.NewReader(strings.NewReader(string(fileContents))).ReadAll(&records); err != nil {
+			log.Fatal(err)
+		}
+
+		// Create a map to store the data
+		cpuPowerCurves = make(map[string]CPUPowerCurve)
+
+		// Iterate over the records and add them to the map
+		for _, record := range records {
+			recordProvider, err := ParseProvider(record.Provider)
+			if err != nil {
+				log.Fatalf("Invalid provider in CPU power curves: %v", err)
+			}
+			watts := []float64{
+				record.Load0, record.Load10, record.Load20, record.Load30, record.Load40, record.Load50,
+				record.Load60, record.Load70, record.Load80, record.Load90, record.Load100,
+			}
+			curve := CPUPowerCurve{
+				Provider:     recordProvider,
+				Architecture: record.Architecture,
+			}
+			for _, w := range watts {
+				curve.Watts = append(curve.Watts, decimal.NewFromFloat(w))
+			}
+			cpuPowerCurves[cpuPowerCurveKey(recordProvider, record.Architecture)] = curve
+		}
+	}
+	curve, ok := cpuPowerCurves[cpuPowerCurveKey(provider, architecture)]
+	if !ok {
+		return nil
+	}
+	return &curve
+}
+
+// WattsAt returns the power of a vCPU at a given utilization (between 0 and 1),
+// linearly interpolated between the two closest measured points
+func (c *CPUPowerCurve) WattsAt(utilization decimal.Decimal) decimal.Decimal {
+	lastPoint := len(c.Watts) - 1
+	if utilization.LessThanOrEqual(decimal.Zero) {
+		return c.Watts[0]
+	}
+	if utilization.GreaterThanOrEqual(decimal.NewFromInt(1)) {
+		return c.Watts[lastPoint]
+	}
+	position := utilization.Mul(decimal.NewFromInt(int64(lastPoint)))
+	lower := position.Floor()
+	fraction := position.Sub(lower)
+	i := int(lower.IntPart())
+	return c.Watts[i].Add(fraction.Mul(c.Watts[i+1].Sub(c.Watts[i])))
+}
+
+func cpuPowerCurveKey(provider Provider, architecture string) string {
+	return strings.ToLower(provider.String() + "/" + architecture)
+}
@@ -0,0 +1,35 @@
+package providers
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCPUPowerCurve_WattsAt(t *testing.T) {
+	curve := CPUPowerCurve{
+		Watts: []decimal.Decimal{
+			decimal.NewFromInt(1), decimal.NewFromInt(2), decimal.NewFromInt(4), decimal.NewFromInt(5),
+			decimal.NewFromInt(6), decimal.NewFromInt(7), decimal.NewFromInt(8), decimal.NewFromInt(9),
+			decimal.NewFromInt(10), decimal.NewFromInt(11), decimal.NewFromInt(12),
+		},
+	}
+	tests := []struct {
+		name        string
+		utilization float64
+		want        string
+	}{
+		{"idle", 0, "1"},
+		{"measured point", 0.2, "4"},
+		{"between points", 0.15, "3"},
+		{"full load", 1, "12"},
+		{"above full load", 1.5, "12"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := curve.WattsAt(decimal.NewFromFloat(tt.utilization))
+			assert.Equal(t, tt.want, got.String())
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package providers
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/carboniferio/carbonifer/internal/data"
+	"github.com/shopspring/decimal"
+	log "github.com/sirupsen/logrus"
+)
+
+var gpuCatalog map[string]GPUModel
+var gpuAliases map[string]string
+
+// AcceleratorKindTPU is the kind of the TPU chips of the GPU catalog
+const AcceleratorKindTPU = "tpu"
+
+// GPUModel is a GPU model of the GPU catalog, or another accelerator estimated like GPUs (like a TPU chip)
+type GPUModel struct {
+	ID   string `json:"-"`
+	Name string `json:"name"`
+	// Kind is the kind of accelerator, empty for GPUs
+	Kind     string          `json:"kind,omitempty"`
+	MinWatts decimal.Decimal `json:"min_watts"`
+	// TDPWatts is the thermal design power of the GPU, used as its max watts
+	TDPWatts decimal.Decimal     `json:"tdp_watts"`
+	MemoryMb int32               `json:"memory_mb"`
+	Aliases  map[string][]string `json:"aliases"`
+}
+
+// GetGPUModel returns the GPU model from its catalog ID or one of its aliases for this provider,
+// or nil if this GPU is unknown
+func GetGPUModel(provider Provider, gpuName string) *GPUModel {
+	// Source: https://www.cloudcarbonfootprint.org/docs/methodology#appendix-iii-gpus-and-minmax-watts
+	log.Debugf("  Getting info for GPU type: %v of %v", gpuName, provider)
+	loadGPUCatalog()
+	id, ok := gpuAliases[gpuAliasKey(provider.String(), gpuName)]
+	if !ok {
+		id = strings.ToLower(gpuName)
+	}
+	gpuModel, ok := gpuCatalog[id]
+	if !ok {
+		return nil
+	}
+	return &gpuModel
+}
+
+// GetLargestGPUModel returns the GPU model of the catalog with the highest TDP, other accelerators excluded
+func GetLargestGPUModel() *GPUModel {
+	loadGPUCatalog()
+	var largest *GPUModel
+	for id := range gpuCatalog {
+		gpuModel := gpuCatalog[id]
+		if gpuModel.Kind != "" {
+			continue
+		}
+		if largest == nil ||
+			gpuModel.TDPWatts.GreaterThan(largest.TDPWatts) ||
+			(gpuModel.TDPWatts.Equal(largest.TDPWatts) && gpuModel.ID < largest.ID) {
+			largest = &gpuModel
+		}
+	}
+	return largest
+}
+
+func loadGPUCatalog() {
+	if gpuCatalog != nil {
+		return
+	}
+	gpuCatalogFile := data.ReadDataFile("gpu_catalog.json")
+	catalog := map[string]GPUModel{}
+	if err := json.Unmarshal(gpuCatalogFile, &catalog); err != nil {
+		log.Fatal(err)
+	}
+
+	gpuCatalog = make(map[string]GPUModel)
+	gpuAliases = make(map[string]string)
+	for id, gpuModel := range catalog {
+		id = strings.ToLower(id)
+		gpuModel.ID = id
+		gpuCatalog[id] = gpuModel
+		for provider, aliases := range gpuModel.Aliases {
+			for _, alias := range aliases {
+				gpuAliases[gpuAliasKey(provider, alias)] = id
+			}
+		}
+	}
+}
+
+func gpuAliasKey(provider string, gpuName string) string {
+	return strings.ToLower(provider + "/" + gpuName)
+}
@@ -0,0 +1,42 @@
+package providers
+
+import (
+	"testing"
+
+	_ "github.com/carboniferio/carbonifer/internal/testutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetGPUModel(t *testing.T) {
+	tests := []struct {
+		name     string
+		provider Provider
+		gpuName  string
+		want     string
+	}{
+		{"catalog ID", GCP, "nvidia-t4", "nvidia-t4"},
+		{"GCP accelerator type", GCP, "nvidia-tesla-t4", "nvidia-t4"},
+		{"AWS name", AWS, "NVIDIA T4", "nvidia-t4"},
+		{"AWS short name", AWS, "t4g", "nvidia-t4"},
+		{"Azure name", AZURE, "NVIDIA Tesla V100", "nvidia-v100"},
+		{"alias of another provider", AWS, "nvidia-tesla-a100", ""},
+		{"GCP TPU", GCP, "tpu-v5litepod", "google-tpu-v5e"},
+		{"unknown", GCP, "foo", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := GetGPUModel(tt.provider, tt.gpuName)
+			if tt.want == "" {
+				assert.Nil(t, got)
+			} else {
+				assert.Equal(t, tt.want, got.ID)
+			}
+		})
+	}
+}
+
+func TestGetLargestGPUModel(t *testing.T) {
+	got := GetLargestGPUModel()
+	assert.Equal(t, "nvidia-a100-40gb", got.ID)
+	assert.Equal(t, "407", got.TDPWatts.String())
+}
@@ -2,9 +2,12 @@ package aws
 
 import (
 	"encoding/json"
+	"strings"
 
 	"github.com/carboniferio/carbonifer/internal/data"
+	"github.com/shopspring/decimal"
 	log "github.com/sirupsen/logrus"
+	"github.com/yunabe/easycsv"
 )
 
 // InstanceType is a struct that contains the information of an AWS instance type
@@ -12,6 +15,9 @@ type InstanceType struct {
 	InstanceType    string          `json:"InstanceType"`
 	VCPU            int32           `json:"VCPU"`
 	MemoryMb        int32           `json:"MemoryMb"`
+	CPUTypes        []string        `json:"CPUTypes"`
+	GPUs            []string        `json:"GPUs"`
+	GPUMemoryMb     int32           `json:"GPUMemoryMb"`
 	InstanceStorage InstanceStorage `json:"InstanceStorage"`
 }
 
@@ -22,7 +28,15 @@ type InstanceStorage struct {
 	Type          string
 }
 
+// CPUWatt is a struct that contains the information of an AWS CPU type
+type CPUWatt struct {
+	Architecture string
+	MinWatts     decimal.Decimal
+	MaxWatts     decimal.Decimal
+}
+
 var awsInstanceTypes map[string]InstanceType
+var awsWattPerCPU map[string]CPUWatt
 
 // GetAWSInstanceType returns the information of an AWS instance type
 func GetAWSInstanceType(instanceTypeStr string) InstanceType {
@@ -37,3 +51,36 @@ func GetAWSInstanceType(instanceTypeStr string) InstanceType {
 
 	return awsInstanceTypes[instanceTypeStr]
 }
+
+type cpuWattCSV struct {
+	Architecture string  `name:"Architecture"`
+	MinWatts     float64 `name:"Min Watts"`
+	MaxWatts     float64 `name:"Max Watts"`
+}
+
+// Source: https://github.com/cloud-carbon-footprint/cloud-carbon-coefficients/blob/main/output/coefficients-aws-use.csv
+// GetCPUWatt returns the min and max watts of a CPU
+func GetCPUWatt(cpu string) CPUWatt {
+	log.Debugf("  Getting info for AWS CPU type: %v", cpu)
+	if awsWattPerCPU == nil {
+		// Read the CSV records
+		var records []cpuWattCSV
+		fileContents := data.ReadDataFile("aws_watt_cpu.csv")
+		if err := easycsv.NewReader(strings.NewReader(string(fileContents))).ReadAll(&records); err != nil {
+			log.Fatal(err)
+		}
+
+		// Create a map to store the data
+		awsWattPerCPU = make(map[string]CPUWatt)
+
+		// Iterate over the records and add them to the map
+		for _, record := range records {
+			awsWattPerCPU[strings.ToLower(record.Architecture)] = CPUWatt{
+				Architecture: record.Architecture,
+				MinWatts:     decimal.NewFromFloat(record.MinWatts),
+				MaxWatts:     decimal.NewFromFloat(record.MaxWatts),
+			}
+		}
+	}
+	return awsWattPerCPU[strings.ToLower(cpu)]
+}
@@ -5,6 +5,8 @@ import (
 	"testing"
 
 	_ "github.com/carboniferio/carbonifer/internal/testutils"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestGetAWSInstanceType(t *testing.T) {
@@ -23,6 +25,8 @@ func TestGetAWSInstanceType(t *testing.T) {
 				InstanceType: "c5d.12xlarge",
 				VCPU:         48,
 				MemoryMb:     96 * 1024,
+				CPUTypes:     []string{"Skylake", "Cascade Lake"},
+				GPUs:         []string{},
 				InstanceStorage: InstanceStorage{
 					SizePerDiskGB: 900,
 					Count:         2,
@@ -39,3 +43,22 @@ func TestGetAWSInstanceType(t *testing.T) {
 		})
 	}
 }
+
+func TestGetAWSInstanceType_GPU(t *testing.T) {
+	got := GetAWSInstanceType("g4dn.12xlarge")
+	assert.Equal(t, []string{"T4", "T4", "T4", "T4"}, got.GPUs)
+	assert.Equal(t, int32(65536), got.GPUMemoryMb)
+}
+
+func TestGetCPUWatt(t *testing.T) {
+	got := GetCPUWatt("Graviton3")
+	want := CPUWatt{
+		Architecture: "Graviton3",
+		MinWatts:     decimal.NewFromFloat(0.4742621527777778),
+		MaxWatts:     decimal.NewFromFloat(1.5751872939814815),
+	}
+	assert.Equal(t, want, got)
+
+	unknown := GetCPUWatt("Unknown")
+	assert.Equal(t, CPUWatt{}, unknown)
+}
@@ -0,0 +1,79 @@
+package azure
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/carboniferio/carbonifer/internal/data"
+	"github.com/shopspring/decimal"
+	log "github.com/sirupsen/logrus"
+	"github.com/yunabe/easycsv"
+)
+
+// VMSize is a struct that contains the information of an Azure virtual machine size
+type VMSize struct {
+	Name     string   `json:"Name"`
+	VCPU     int32    `json:"VCPU"`
+	MemoryMb int32    `json:"MemoryMb"`
+	CPUTypes []string `json:"CPUTypes"`
+	GPUs     []string `json:"GPUs"`
+	// TempDiskSizeGB is the size of the local temporary disk (SSD) of the VM
+	TempDiskSizeGB int64 `json:"TempDiskSizeGB"`
+}
+
+// CPUWatt is a struct that contains the information of an Azure CPU type
+type CPUWatt struct {
+	Architecture string
+	MinWatts     decimal.Decimal
+	MaxWatts     decimal.Decimal
+}
+
+var azureVMSizes map[string]VMSize
+var azureWattPerCPU map[string]CPUWatt
+
+// GetAzureVMSize returns the information of an Azure virtual machine size
+func GetAzureVMSize(vmSizeStr string) VMSize {
+	log.Debugf("  Getting info for Azure VM size: %v", vmSizeStr)
+	if azureVMSizes == nil {
+		byteValue := data.ReadDataFile("azure_instances.json")
+		err := json.Unmarshal([]byte(byteValue), &azureVMSizes)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	return azureVMSizes[vmSizeStr]
+}
+
+type cpuWattCSV struct {
+	Architecture string  `name:"Architecture"`
+	MinWatts     float64 `name:"Min Watts"`
+	MaxWatts     float64 `name:"Max Watts"`
+}
+
+// Source: https://github.com/cloud-carbon-footprint/cloud-carbon-coefficients/blob/main/output/coefficients-azure-use.csv
+// GetCPUWatt returns the min and max watts of a CPU
+func GetCPUWatt(cpu string) CPUWatt {
+	log.Debugf("  Getting info for Azure CPU type: %v", cpu)
+	if azureWattPerCPU == nil {
+		// Read the CSV records
+		var records []cpuWattCSV
+		fileContents := data.ReadDataFile("azure_watt_cpu.csv")
+		if err := easycsv.NewReader(strings.NewReader(string(fileContents))).ReadAll(&records); err != nil {
+			log.Fatal(err)
+		}
+
+		// Create a map to store the data
+		azureWattPerCPU = make(map[string]CPUWatt)
+
+		// Iterate over the records and add them to the map
+		for _, record := range records {
+			azureWattPerCPU[strings.ToLower(record.Architecture)] = CPUWatt{
+				Architecture: record.Architecture,
+				MinWatts:     decimal.NewFromFloat(record.MinWatts),
+				MaxWatts:     decimal.NewFromFloat(record.MaxWatts),
+			}
+		}
+	}
+	return azureWattPerCPU[strings.ToLower(cpu)]
+}
@@ -0,0 +1,43 @@
+package azure
+
+import (
+	"testing"
+
+	_ "github.com/carboniferio/carbonifer/internal/testutils"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetAzureVMSize(t *testing.T) {
+	got := GetAzureVMSize("Standard_D4s_v3")
+	want := VMSize{
+		Name:           "Standard_D4s_v3",
+		VCPU:           4,
+		MemoryMb:       16 * 1024,
+		CPUTypes:       []string{"Broadwell", "Skylake", "Cascade Lake", "Haswell"},
+		GPUs:           []string{},
+		TempDiskSizeGB: 32,
+	}
+	assert.Equal(t, want, got)
+
+	unknown := GetAzureVMSize("Standard_Unknown")
+	assert.Equal(t, VMSize{}, unknown)
+}
+
+func TestGetAzureVMSize_GPU(t *testing.T) {
+	got := GetAzureVMSize("Standard_NC64as_T4_v3")
+	assert.Equal(t, []string{"NVIDIA T4", "NVIDIA T4", "NVIDIA T4", "NVIDIA T4"}, got.GPUs)
+}
+
+func TestGetCPUWatt(t *testing.T) {
+	got := GetCPUWatt("epyc 1st gen")
+	want := CPUWatt{
+		Architecture: "EPYC 1st Gen",
+		MinWatts:     decimal.NewFromFloat(0.82),
+		MaxWatts:     decimal.NewFromFloat(2.55),
+	}
+	assert.Equal(t, want, got)
+
+	unknown := GetCPUWatt("Unknown")
+	assert.Equal(t, CPUWatt{}, unknown)
+}
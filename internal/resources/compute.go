@@ -10,9 +10,23 @@ type ComputeResourceSpecs struct {
 	GpuTypes   []string
 	HddStorage decimal.Decimal
 	SsdStorage decimal.Decimal
-	MemoryMb   int32
-	VCPUs      int32
-	CPUType    string
+	// Number of copies of the data written by the provider (averaged over the disks), applied to storage only. Zero if not replicated
+	HddStorageReplicationFactor decimal.Decimal
+	SsdStorageReplicationFactor decimal.Decimal
+	MemoryMb                    int32
+	VCPUs                       int32
+	// FractionalVCPUs is the number of vCPUs of a container (like a Fargate task), that can be a fraction of a vCPU.
+	// It is used instead of VCPUs if set
+	FractionalVCPUs decimal.Decimal
+	CPUType         string
+}
+
+// GetVCPUs returns the number of vCPUs, fractional for containers
+func (s *ComputeResourceSpecs) GetVCPUs() decimal.Decimal {
+	if !s.FractionalVCPUs.IsZero() {
+		return s.FractionalVCPUs
+	}
+	return decimal.NewFromInt32(s.VCPUs)
 }
 
 // ResourceIdentification is the struct that contains the identification of a resource
@@ -25,12 +39,77 @@ type ResourceIdentification struct {
 	Count             int64
 	ReplicationFactor int32
 	Address           string
+	// Lifecycle is the purchasing model of the capacity, empty for on-demand
+	Lifecycle string `json:"Lifecycle,omitempty"`
 }
 
+const (
+	// LifecycleOnDemand is a regular capacity, running until stopped by the user
+	LifecycleOnDemand = "on_demand"
+	// LifecycleSpot is a spare capacity that can be reclaimed by the provider (AWS spot, GCP spot VM)
+	LifecycleSpot = "spot"
+	// LifecyclePreemptible is a GCP preemptible VM, stopped by the provider after 24 hours at most
+	LifecyclePreemptible = "preemptible"
+)
+
 // ComputeResource is the struct that contains the info of a compute resource
 type ComputeResource struct {
 	Identification *ResourceIdentification
 	Specs          *ComputeResourceSpecs
+	// MixedInstances is set if the instances are of several types and lifecycles, Specs is then only used for storage
+	MixedInstances *MixedInstances `json:"MixedInstances,omitempty"`
+	// Serverless is set if the resource runs on demand (functions, serverless containers), its running time
+	// depends on its usage
+	Serverless *Serverless `json:"Serverless,omitempty"`
+	// ObjectStorage is set for buckets, whose stored size depends on their usage
+	ObjectStorage bool `json:"ObjectStorage,omitempty"`
+	// PodRequests is set if the capacity of the resource is the resources requested by its pods (like GKE Autopilot),
+	// that can be declared in usages
+	PodRequests bool `json:"PodRequests,omitempty"`
+	// Cluster is the address of the Kubernetes cluster the resource is a node pool of, empty if none
+	Cluster string `json:"Cluster,omitempty"`
+	// DedicatedHost is the address of the dedicated host (or sole-tenant node group) the resource is placed on, whose
+	// estimation already counts its compute, empty if none
+	DedicatedHost string `json:"DedicatedHost,omitempty"`
+	// FixedPower is set if the power of the resource is taken from a fixed profile (like networking appliances)
+	// instead of its specs
+	FixedPower *FixedPower `json:"FixedPower,omitempty"`
+}
+
+// FixedPower is the fixed power profile of a resource whose hardware is not known
+type FixedPower struct {
+	// Watts is the power of an instance of the resource
+	Watts decimal.Decimal
+	// WattsPerUnit is the additional power per configured capacity unit
+	WattsPerUnit decimal.Decimal
+	// CapacityUnits is the configured capacity of an instance of the resource
+	CapacityUnits decimal.Decimal
+}
+
+// Serverless is the scaling of a resource running on demand
+type Serverless struct {
+	// MinInstances is the number of instances kept running without requests
+	MinInstances int64
+	// Concurrency is the number of requests an instance handles at the same time
+	Concurrency int64
+}
+
+// MixedInstances is a group of instances of several types, on-demand or spot (like an AWS mixed instances policy).
+// The count of the resource is in capacity units
+type MixedInstances struct {
+	InstanceTypes []MixedInstanceType
+	// OnDemandBaseCapacity is the capacity always fulfilled by on-demand instances
+	OnDemandBaseCapacity int64
+	// OnDemandPercentageAboveBaseCapacity is the percentage of on-demand instances above the base capacity, the others are spot
+	OnDemandPercentageAboveBaseCapacity decimal.Decimal
+}
+
+// MixedInstanceType is an instance type of mixed instances
+type MixedInstanceType struct {
+	InstanceType string
+	// WeightedCapacity is the number of capacity units of an instance of this type
+	WeightedCapacity decimal.Decimal
+	Specs            *ComputeResourceSpecs
 }
 
 // IsSupported returns true if the resource is supported, false otherwise
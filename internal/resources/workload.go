@@ -0,0 +1,36 @@
+package resources
+
+import "github.com/shopspring/decimal"
+
+// WorkloadResource is a workload running on a host resource, like an ECS service on EC2 container instances
+// or a Kubernetes deployment on a cluster. Its emissions are a share of the emissions of its host, already
+// counted in the host
+type WorkloadResource struct {
+	Identification *ResourceIdentification
+	// HostAddress is the address of the resource running the workload (or of the cluster whose node pools run it),
+	// empty if unknown
+	HostAddress string
+	// Namespace is the Kubernetes namespace of the workload, empty if none
+	Namespace string `json:"Namespace,omitempty"`
+	// VCPUs and MemoryMb are reserved by each copy of the workload (Count copies)
+	VCPUs    decimal.Decimal
+	MemoryMb decimal.Decimal
+	// PerNode is set if a copy of the workload runs on each node of the host (like a Kubernetes DaemonSet),
+	// Count is then ignored
+	PerNode bool `json:"PerNode,omitempty"`
+}
+
+// IsSupported returns true if the host of the workload is known, false otherwise
+func (r WorkloadResource) IsSupported() bool {
+	return r.HostAddress != ""
+}
+
+// GetIdentification returns the identification of the resource
+func (r WorkloadResource) GetIdentification() *ResourceIdentification {
+	return r.Identification
+}
+
+// GetAddress returns the address of the resource
+func (r WorkloadResource) GetAddress() string {
+	return r.Identification.Address
+}
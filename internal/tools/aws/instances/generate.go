@@ -5,6 +5,7 @@ package main
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/session"
@@ -19,6 +20,7 @@ type instanceType struct {
 	InstanceType    string
 	VCPU            int64
 	MemoryMb        int64
+	CPUTypes        []string
 	GPUs            []string
 	GPUMemoryMb     int64
 	InstanceStorage *instanceStorage
@@ -30,6 +32,113 @@ type instanceStorage struct {
 	Type          string
 }
 
+// cpuTypesPerFamily is the processor microarchitecture of each instance family, as it is not returned by the API.
+// Source: https://aws.amazon.com/ec2/instance-types/
+var cpuTypesPerFamily = map[string][]string{
+	"a1":       {"Graviton"},
+	"c3":       {"Ivy Bridge"},
+	"c4":       {"Haswell"},
+	"c5":       {"Skylake", "Cascade Lake"},
+	"c5a":      {"EPYC 2nd Gen"},
+	"c5ad":     {"EPYC 2nd Gen"},
+	"c5d":      {"Skylake", "Cascade Lake"},
+	"c5n":      {"Skylake"},
+	"c6a":      {"EPYC 3rd Gen"},
+	"c6g":      {"Graviton2"},
+	"c6gd":     {"Graviton2"},
+	"c6gn":     {"Graviton2"},
+	"c6i":      {"Ice Lake"},
+	"c6id":     {"Ice Lake"},
+	"c6in":     {"Ice Lake"},
+	"c7a":      {"EPYC 4th Gen"},
+	"c7g":      {"Graviton3"},
+	"c7gd":     {"Graviton3"},
+	"c7gn":     {"Graviton3"},
+	"c7i":      {"Sapphire Rapids"},
+	"d2":       {"Haswell"},
+	"d3":       {"Cascade Lake"},
+	"d3en":     {"Cascade Lake"},
+	"dl1":      {"Cascade Lake"},
+	"f1":       {"Broadwell"},
+	"g2":       {"Sandy Bridge"},
+	"g3":       {"Broadwell"},
+	"g3s":      {"Broadwell"},
+	"g4ad":     {"EPYC 2nd Gen"},
+	"g4dn":     {"Cascade Lake"},
+	"g5":       {"EPYC 2nd Gen"},
+	"g5g":      {"Graviton2"},
+	"h1":       {"Broadwell"},
+	"i2":       {"Ivy Bridge"},
+	"i3":       {"Broadwell"},
+	"i3en":     {"Skylake", "Cascade Lake"},
+	"i4g":      {"Graviton2"},
+	"i4i":      {"Ice Lake"},
+	"im4gn":    {"Graviton2"},
+	"inf1":     {"Cascade Lake"},
+	"inf2":     {"EPYC 3rd Gen"},
+	"is4gen":   {"Graviton2"},
+	"m3":       {"Ivy Bridge"},
+	"m4":       {"Broadwell", "Haswell"},
+	"m5":       {"Skylake", "Cascade Lake"},
+	"m5d":      {"Skylake", "Cascade Lake"},
+	"m5dn":     {"Cascade Lake"},
+	"m5n":      {"Cascade Lake"},
+	"m5zn":     {"Cascade Lake"},
+	"m6a":      {"EPYC 3rd Gen"},
+	"m6g":      {"Graviton2"},
+	"m6gd":     {"Graviton2"},
+	"m6i":      {"Ice Lake"},
+	"m6id":     {"Ice Lake"},
+	"m6idn":    {"Ice Lake"},
+	"m6in":     {"Ice Lake"},
+	"m7a":      {"EPYC 4th Gen"},
+	"m7g":      {"Graviton3"},
+	"m7gd":     {"Graviton3"},
+	"m7i":      {"Sapphire Rapids"},
+	"m7i-flex": {"Sapphire Rapids"},
+	"p2":       {"Broadwell"},
+	"p3":       {"Broadwell"},
+	"p3dn":     {"Skylake"},
+	"p4d":      {"Cascade Lake"},
+	"r3":       {"Ivy Bridge"},
+	"r4":       {"Broadwell"},
+	"r5":       {"Skylake", "Cascade Lake"},
+	"r5b":      {"Cascade Lake"},
+	"r5d":      {"Skylake", "Cascade Lake"},
+	"r5dn":     {"Cascade Lake"},
+	"r5n":      {"Cascade Lake"},
+	"r6a":      {"EPYC 3rd Gen"},
+	"r6g":      {"Graviton2"},
+	"r6gd":     {"Graviton2"},
+	"r6i":      {"Ice Lake"},
+	"r6id":     {"Ice Lake"},
+	"r6idn":    {"Ice Lake"},
+	"r6in":     {"Ice Lake"},
+	"r7a":      {"EPYC 4th Gen"},
+	"r7g":      {"Graviton3"},
+	"r7gd":     {"Graviton3"},
+	"r7i":      {"Sapphire Rapids"},
+	"t2":       {"Haswell", "Broadwell"},
+	"t3":       {"Skylake", "Cascade Lake"},
+	"t4g":      {"Graviton2"},
+	"trn1":     {"Ice Lake"},
+	"trn1n":    {"Ice Lake"},
+	"u-12tb1":  {"Skylake"},
+	"u-18tb1":  {"Cascade Lake"},
+	"u-24tb1":  {"Cascade Lake"},
+	"u-3tb1":   {"Skylake"},
+	"u-6tb1":   {"Skylake"},
+	"u-9tb1":   {"Skylake"},
+	"vt1":      {"Cascade Lake"},
+	"x1":       {"Haswell"},
+	"x1e":      {"Haswell"},
+	"x2gd":     {"Graviton2"},
+	"x2idn":    {"Ice Lake"},
+	"x2iedn":   {"Ice Lake"},
+	"x2iezn":   {"Cascade Lake"},
+	"z1d":      {"Skylake"},
+}
+
 // Generate writes the list of instances types in a json to stdout
 func main() {
 	// Create a EC2 service client.
@@ -72,7 +181,9 @@ func describeInstanceTypesPaginated(svc *ec2.EC2, instances *map[string]instance
 		gpus := []string{}
 		if gpuInfos != nil {
 			for _, gpu := range gpuInfos.Gpus {
-				gpus = append(gpus, *gpu.Name)
+				for i := int64(0); i < *gpu.Count; i++ {
+					gpus = append(gpus, *gpu.Name)
+				}
 			}
 			totalGPUMemoryMb = *gpuInfos.TotalGpuMemoryInMiB
 		}
@@ -85,10 +196,16 @@ func describeInstanceTypesPaginated(svc *ec2.EC2, instances *map[string]instance
 			}
 		}
 		name := *instanceTypeInfo.InstanceType
+		cpuTypes, ok := cpuTypesPerFamily[strings.Split(name, ".")[0]]
+		if !ok {
+			log.Warnf("Unknown CPU type for instance type %v", name)
+			cpuTypes = []string{}
+		}
 		instance := instanceType{
 			InstanceType:    name,
 			VCPU:            *instanceTypeInfo.VCpuInfo.DefaultVCpus,
 			MemoryMb:        *instanceTypeInfo.MemoryInfo.SizeInMiB,
+			CPUTypes:        cpuTypes,
 			GPUs:            gpus,
 			GPUMemoryMb:     int64(totalGPUMemoryMb),
 			InstanceStorage: &instanceStorageInfo,
@@ -0,0 +1,204 @@
+// Get the list of all virtual machine sizes of Azure and write them to a json to stdout with their attributes (cpu, memory, etc).
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+
+	"github.com/pkg/errors"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// vmSize is the struct that will be exported in the json
+type vmSize struct {
+	Name           string
+	VCPU           int64
+	MemoryMb       int64
+	CPUTypes       []string
+	GPUs           []string
+	TempDiskSizeGB int64
+}
+
+type resourceSkus struct {
+	Value    []resourceSku `json:"value"`
+	NextLink string        `json:"nextLink"`
+}
+
+type resourceSku struct {
+	ResourceType string `json:"resourceType"`
+	Name         string `json:"name"`
+	Family       string `json:"family"`
+	Capabilities []struct {
+		Name  string `json:"name"`
+		Value string `json:"value"`
+	} `json:"capabilities"`
+}
+
+// cpuTypesPerFamily is the processor microarchitecture of each VM family, as it is not returned by the API.
+// Source: https://learn.microsoft.com/en-us/azure/virtual-machines/sizes
+var cpuTypesPerFamily = map[string][]string{
+	"standardBSFamily":           {"Haswell", "Broadwell", "Skylake", "Cascade Lake"},
+	"standardBsv2Family":         {"Ice Lake", "Sapphire Rapids"},
+	"standardBmsv2Family":        {"Ice Lake", "Sapphire Rapids"},
+	"standardDv2Family":          {"Haswell", "Broadwell", "Skylake", "Cascade Lake"},
+	"standardDSv2Family":         {"Haswell", "Broadwell", "Skylake", "Cascade Lake"},
+	"standardDv3Family":          {"Broadwell", "Skylake", "Cascade Lake", "Haswell"},
+	"standardDSv3Family":         {"Broadwell", "Skylake", "Cascade Lake", "Haswell"},
+	"standardEv3Family":          {"Broadwell", "Skylake", "Cascade Lake"},
+	"standardESv3Family":         {"Broadwell", "Skylake", "Cascade Lake"},
+	"standardDv4Family":          {"Cascade Lake"},
+	"standardDSv4Family":         {"Cascade Lake"},
+	"standardDDv4Family":         {"Cascade Lake"},
+	"standardDDSv4Family":        {"Cascade Lake"},
+	"standardEv4Family":          {"Cascade Lake"},
+	"standardESv4Family":         {"Cascade Lake"},
+	"standardEDSv4Family":        {"Cascade Lake"},
+	"standardDASv4Family":        {"EPYC 2nd Gen"},
+	"standardEASv4Family":        {"EPYC 2nd Gen"},
+	"standardDv5Family":          {"Ice Lake"},
+	"standardDSv5Family":         {"Ice Lake"},
+	"standardDDSv5Family":        {"Ice Lake"},
+	"standardEv5Family":          {"Ice Lake"},
+	"standardESv5Family":         {"Ice Lake"},
+	"standardEDSv5Family":        {"Ice Lake"},
+	"standardDASv5Family":        {"EPYC 3rd Gen"},
+	"standardDADSv5Family":       {"EPYC 3rd Gen"},
+	"standardEASv5Family":        {"EPYC 3rd Gen"},
+	"standardEADSv5Family":       {"EPYC 3rd Gen"},
+	"standardFSv2Family":         {"Skylake", "Cascade Lake"},
+	"standardLSv2Family":         {"EPYC 1st Gen"},
+	"standardLSv3Family":         {"Ice Lake"},
+	"standardMSFamily":           {"Broadwell", "Skylake"},
+	"standardNCFamily":           {"Haswell"},
+	"standardNVFamily":           {"Haswell"},
+	"standardNCSv2Family":        {"Broadwell"},
+	"standardNCSv3Family":        {"Broadwell"},
+	"standardNDSFamily":          {"Broadwell"},
+	"standardNVSv3Family":        {"Broadwell"},
+	"standardNCASv3_T4Family":    {"EPYC 2nd Gen"},
+	"standardNCADSA100v4Family":  {"EPYC 3rd Gen"},
+	"standardNVADSA10v5Family":   {"EPYC 3rd Gen"},
+	"standardNDSv2Family":        {"Skylake"},
+	"standardNDASv4_A100Family":  {"EPYC 2nd Gen"},
+	"standardNDAMSv4_A100Family": {"EPYC 2nd Gen"},
+}
+
+// gpuPerFamily is the GPU model of each VM family, as only the number of GPUs is returned by the API.
+// Names are the Azure aliases of the GPU catalog (data/gpu_catalog.json)
+var gpuPerFamily = map[string]string{
+	"standardNCFamily":           "NVIDIA Tesla K80",
+	"standardNVFamily":           "NVIDIA Tesla M60",
+	"standardNCSv2Family":        "NVIDIA Tesla P100",
+	"standardNCSv3Family":        "NVIDIA Tesla V100",
+	"standardNDSFamily":          "NVIDIA Tesla P40",
+	"standardNVSv3Family":        "NVIDIA Tesla M60",
+	"standardNCASv3_T4Family":    "NVIDIA T4",
+	"standardNCADSA100v4Family":  "NVIDIA A100 80GB",
+	"standardNVADSA10v5Family":   "NVIDIA A10",
+	"standardNDSv2Family":        "NVIDIA Tesla V100",
+	"standardNDASv4_A100Family":  "NVIDIA A100",
+	"standardNDAMSv4_A100Family": "NVIDIA A100 80GB",
+}
+
+// Generate writes the list of virtual machine sizes in a json to stdout
+// It needs AZURE_SUBSCRIPTION_ID and AZURE_ACCESS_TOKEN (`az account get-access-token --query accessToken -o tsv`)
+func main() {
+	subscriptionID := os.Getenv("AZURE_SUBSCRIPTION_ID")
+	accessToken := os.Getenv("AZURE_ACCESS_TOKEN")
+	if subscriptionID == "" || accessToken == "" {
+		log.Fatal("AZURE_SUBSCRIPTION_ID and AZURE_ACCESS_TOKEN must be set")
+	}
+
+	// Get the list of VM sizes, the same size is listed once per location
+	vmSizes := map[string]vmSize{}
+	url := fmt.Sprintf("https://management.azure.com/subscriptions/%s/providers/Microsoft.Compute/skus?api-version=2021-07-01", subscriptionID)
+	for url != "" {
+		url = listResourceSkusPaginated(url, accessToken, &vmSizes)
+	}
+
+	// Write the list of VM sizes to stdout
+	json, err := json.MarshalIndent(vmSizes, "", "  ")
+	if err != nil {
+		errW := errors.Wrap(err, "cannot marshal VM sizes to json")
+		log.Panic(errW)
+	}
+	fmt.Println(string(json))
+}
+
+func listResourceSkusPaginated(url string, accessToken string, vmSizes *map[string]vmSize) string {
+	request, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		log.Panic(errors.Wrap(err, "cannot create request"))
+	}
+	request.Header.Set("Authorization", "Bearer "+accessToken)
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		log.Panic(errors.Wrap(err, "cannot list resource skus"))
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		log.Panicf("cannot list resource skus: %v", response.Status)
+	}
+	var skus resourceSkus
+	if err := json.NewDecoder(response.Body).Decode(&skus); err != nil {
+		log.Panic(errors.Wrap(err, "cannot decode resource skus"))
+	}
+
+	for _, sku := range skus.Value {
+		if sku.ResourceType != "virtualMachines" {
+			continue
+		}
+		if _, ok := (*vmSizes)[sku.Name]; ok {
+			continue
+		}
+		capabilities := map[string]string{}
+		for _, capability := range sku.Capabilities {
+			capabilities[capability.Name] = capability.Value
+		}
+		cpuTypes, ok := cpuTypesPerFamily[sku.Family]
+		if !ok {
+			log.Warnf("Unknown CPU type for VM size %v (%v)", sku.Name, sku.Family)
+			cpuTypes = []string{}
+		}
+		gpus := []string{}
+		gpuCount := parseCapability(capabilities, "GPUs")
+		if gpuCount > 0 {
+			gpuName, ok := gpuPerFamily[sku.Family]
+			if !ok {
+				log.Warnf("Unknown GPU for VM size %v (%v)", sku.Name, sku.Family)
+				gpuName = sku.Family
+			}
+			for i := int64(0); i < gpuCount; i++ {
+				gpus = append(gpus, gpuName)
+			}
+		}
+		memoryGb, _ := strconv.ParseFloat(capabilities["MemoryGB"], 64)
+		(*vmSizes)[sku.Name] = vmSize{
+			Name:           sku.Name,
+			VCPU:           parseCapability(capabilities, "vCPUs"),
+			MemoryMb:       int64(memoryGb * 1024),
+			CPUTypes:       cpuTypes,
+			GPUs:           gpus,
+			TempDiskSizeGB: parseCapability(capabilities, "MaxResourceVolumeMB") / 1024,
+		}
+	}
+	return skus.NextLink
+}
+
+func parseCapability(capabilities map[string]string, name string) int64 {
+	value, ok := capabilities[name]
+	if !ok {
+		return 0
+	}
+	intValue, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		log.Warnf("Cannot parse capability %v '%v': %v", name, value, err)
+		return 0
+	}
+	return intValue
+}
@@ -27,6 +27,95 @@ func (*moduleLoader) LoadModule(name string) (*gojq.Query, error) {
 				else
 				  "Unknown format"
 				end;
+
+			# CPU of a Kubernetes quantity ("250m", "2"), in vCPUs
+			def k8s_cpu:
+				if . == null then null
+				else tostring | if endswith("m") then (.[:-1] | tonumber) / 1000 else tonumber end
+				end;
+
+			# Memory of a Kubernetes quantity ("512Mi", "1G"), in MiB
+			def k8s_memory_mb:
+				if . == null then null
+				else
+				  tostring | capture("^(?<value>[0-9.]+)\\s*(?<unit>[A-Za-z]*)$")
+				  | (.value | tonumber) * ({"k": (1000 / 1048576), "Ki": (1 / 1024), "M": (1000000 / 1048576), "Mi": 1, "G": (1000000000 / 1048576), "Gi": 1024, "T": (1000000000000 / 1048576), "Ti": 1048576}[.unit] // (1 / 1048576))
+				end;
+
+			# Requests of the containers of a Kubernetes workload (limits if no requests, as Kubernetes does)
+			def k8s_containers:
+				.values.spec[0]?.template[0]?.spec[0]?.container[]?
+				| (.resources[0]?.requests // {}) as $requests
+				| (.resources[0]?.limits // {}) as $limits
+				| {cpu: (($requests.cpu // $limits.cpu) | k8s_cpu), memory_mb: (($requests.memory // $limits.memory) | k8s_memory_mb)};
+
+			def k8s_replicas:
+				(.values.spec[0]?.replicas // 1) | tonumber;
+
+			# Cluster referenced by the host of the Kubernetes provider of a resource, from the configuration of the plan
+			def k8s_cluster($address):
+				($address | sub("\\[[^\\]]*\\]$"; "")) as $configAddress
+				| . as $config
+				| first(
+				    $config.root_module.resources[]?
+				    | select(.address == $configAddress)
+				    | (.provider_config_key // "kubernetes") as $providerConfigKey
+				    | $config.provider_config[$providerConfigKey]?.expressions.host.references[]?
+				    | select(test("^[a-z0-9_]+\\.[^.]+$"))
+				  );
+
+			# References of the configuration of a resource of the plan, as addresses of the plan, from the configuration
+			# of the plan. The configuration of a resource in a module is in the call of its module, and the references to
+			# the variables of a module are followed to the expressions of its call
+			def config_references($address; references):
+				. as $config
+				| ($address | capture("^(?<modules>(module\\.[^.\\[]+(\\[[^\\]]*\\])?\\.)*)(?<resource>.+)$")) as $parts
+				| [$parts.modules | scan("module\\.([^.\\[]+)((?:\\[[^\\]]*\\])?)\\.") | {name: .[0], prefix: ("module." + .[0] + .[1] + ".")}] as $modules
+				| ($parts.resource | sub("\\[[^\\]]*\\]$"; "")) as $configAddress
+				| def module_config($depth): reduce $modules[:$depth][] as $module ($config.root_module; .module_calls[$module.name]?.module);
+				  def resolve($depth):
+				    if startswith("var.") and $depth > 0 then
+				      capture("^var\\.(?<variable>[^.\\[]+)").variable as $variable
+				      | module_config($depth - 1).module_calls[$modules[$depth - 1].name]?.expressions[$variable]?.references[]?
+				      | resolve($depth - 1)
+				    elif test("^(var|local|each|count|path|self|terraform|module)\\.") then
+				      empty
+				    else
+				      ([$modules[:$depth][].prefix] | join("")) + .
+				    end;
+				  module_config($modules | length).resources[]?
+				  | select(.address == $configAddress)
+				  | references
+				  | resolve($modules | length);
+
+			# Dedicated host (AWS) or sole-tenant node group (GCP) of the plan a VM is placed on, by the host_id or the
+			# node group name of the VM, or by the references of its configuration
+			def dedicated_host($vm):
+				. as $plan
+				| ($vm.address | sub("\\[[^\\]]*\\]$"; "")) as $configAddress
+				| [$vm.values.host_id // empty] as $hostIds
+				| [$vm.values.scheduling[0]?.node_affinities[]? | select(.key == "compute.googleapis.com/node-group-name" and (.operator // "IN") == "IN") | .values[]?] as $groupNames
+				| [$plan.configuration.root_module.resources[]? | select(.address == $configAddress) | (.expressions.host_id?.references[]?, .expressions.scheduling[0]?.node_affinities[]?.values?.references[]?) | sub("\\.(id|arn|name|self_link)$"; "")] as $references
+				| first(
+				    ($plan | all_select("type"; "aws_ec2_host"), all_select("type"; "google_compute_node_group"))
+				    | . as $host
+				    | select(
+				        (.values.id != null and any($hostIds[]; . == $host.values.id))
+				        or (.type == "google_compute_node_group" and any($groupNames[]; . == $host.values.name))
+				        or any($references[]; . as $reference | $host.address == $reference or ($host.address | startswith($reference + "[")))
+				      )
+				    | .address
+				  );
+
+			# Resources of a pod of a Kubernetes workload in GKE Autopilot, from the requests of its containers:
+			# 0.5 vCPU and 2 GiB by default, at least 0.25 vCPU and 0.5 GiB, vCPUs by steps of 0.25,
+			# and between 1 and 6.5 GiB per vCPU
+			def autopilot_pod:
+				[k8s_containers | {cpu: (.cpu // 0.5), memory_mb: (.memory_mb // 2048)}]
+				| {cpu: (map(.cpu) | add // 0.5), memory_mb: (map(.memory_mb) | add // 2048)}
+				| .cpu = ([.cpu, 0.25] | max * 4 | ceil / 4)
+				| .memory_mb = ([.memory_mb, 512, .cpu * 1024] | max)
+				| .cpu = ([.cpu, (.memory_mb / 1024 / 6.5 * 4 | ceil / 4)] | max);
 		`)
 	}
 	return nil, fmt.Errorf("module not found: %q", name)
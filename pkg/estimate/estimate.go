@@ -14,6 +14,7 @@ type EstimationReport struct {
 	Resource        resources.GenericResource
 	Power           decimal.Decimal `json:"PowerPerInstance"`
 	CarbonEmissions decimal.Decimal `json:"CarbonEmissionsPerInstance"`
+	Water           decimal.Decimal `json:"WaterPerInstance"`
 	AverageCPUUsage decimal.Decimal
 	Count           decimal.Decimal
 }
@@ -32,6 +33,7 @@ func GetEstimation(resource resources.GenericResource) (EstimationReport, error)
 		Resource:        resource,
 		Power:           estimation.Power.Truncate(10),
 		CarbonEmissions: estimation.CarbonEmissions.Truncate(10),
+		Water:           estimation.Water.Truncate(10),
 		AverageCPUUsage: estimation.AverageCPUUsage.Truncate(10),
 		Count:           estimation.TotalCount.Truncate(10),
 	}, nil
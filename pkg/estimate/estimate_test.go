@@ -56,8 +56,9 @@ func TestGetEstimation(t *testing.T) {
 					MemoryMb: 8192,
 					VCPUs:    2,
 				},
-				Power:           decimal.NewFromFloatWithExponent(8.9166, -10), // Refer to estimate.go for other indications
-				CarbonEmissions: decimal.NewFromFloatWithExponent(2.5233978, -10),
+				Power:           decimal.NewFromFloatWithExponent(8.75448, -10), // Refer to estimate.go for other indications
+				CarbonEmissions: decimal.NewFromFloatWithExponent(2.47751784, -10),
+				Water:           decimal.NewFromFloatWithExponent(0.00786282, -10),
 				AverageCPUUsage: decimal.NewFromFloat(0.5),
 				Count:           decimal.NewFromInt(1),
 			},